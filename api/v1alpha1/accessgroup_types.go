@@ -0,0 +1,111 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessGroupSpec defines the desired state of AccessGroup
+type AccessGroupSpec struct {
+	// Tier is the access tier granted to the subjects, one of customer-admins, customer-readers,
+	// giantswarm-admins or the name of a configured access tier
+	// +kubebuilder:validation:MinLength=1
+	Tier string `json:"tier"`
+
+	// Subjects are granted the access tier
+	// +kubebuilder:validation:MinItems=1
+	Subjects []AccessGroupSubject `json:"subjects"`
+
+	// Organizations restricts the access to the namespaces of the given organizations.
+	// Access is granted to all organizations and cluster-wide if empty.
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+// AccessGroupSubject is a group, user or service account granted an access tier
+type AccessGroupSubject struct {
+	// Kind of the subject, Group if not set
+	// +kubebuilder:validation:Enum=Group;User;ServiceAccount
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the subject, only and always set for ServiceAccounts
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AccessGroupStatus defines the observed state of AccessGroup
+type AccessGroupStatus struct {
+	// Bindings contains the ClusterRoleBindings and RoleBindings managed by rbac-operator
+	// which bind the subjects of the AccessGroup
+	// +optional
+	Bindings []AccessGroupBinding `json:"bindings,omitempty"`
+
+	// ObservedGeneration is the generation of the AccessGroup the status was last computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the AccessGroup
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// AccessGroupBinding references a ClusterRoleBinding or RoleBinding binding the subjects of an AccessGroup
+type AccessGroupBinding struct {
+	// Kind is either ClusterRoleBinding or RoleBinding
+	Kind string `json:"kind"`
+	// Namespace of the RoleBinding, empty for ClusterRoleBindings
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// ClusterRole referenced by the binding
+	ClusterRole string `json:"clusterRole"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Tier",type=string,JSONPath=`.spec.tier`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// AccessGroup is the Schema for the accessgroups API
+type AccessGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessGroupSpec   `json:"spec,omitempty"`
+	Status AccessGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AccessGroupList contains a list of AccessGroup
+type AccessGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccessGroup{}, &AccessGroupList{})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterRoleBindingTemplateSpec defines the desired state of ClusterRoleBindingTemplate
+type ClusterRoleBindingTemplateSpec struct {
+	Template ClusterRoleBindingTemplateResource `json:"template"`
+	Scopes   ClusterRoleBindingTemplateScopes   `json:"scopes"`
+}
+
+// ClusterRoleBindingTemplateStatus defines the observed state of ClusterRoleBindingTemplate
+type ClusterRoleBindingTemplateStatus struct {
+	// ClusterRoleBindings contains a list of ClusterRoleBindings currently rendered from the template
+	ClusterRoleBindings []string `json:"clusterRoleBindings,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// ClusterRoleBindingTemplate is the Schema for the clusterrolebindingtemplates API
+type ClusterRoleBindingTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterRoleBindingTemplateSpec   `json:"spec,omitempty"`
+	Status ClusterRoleBindingTemplateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterRoleBindingTemplateList contains a list of ClusterRoleBindingTemplate
+type ClusterRoleBindingTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRoleBindingTemplate `json:"items"`
+}
+
+// ClusterRoleBindingTemplateResource describes the data needed to create a clusterrolebinding from a template.
+// One ClusterRoleBinding is rendered per organization in scope, named after the template and the organization.
+type ClusterRoleBindingTemplateResource struct {
+	// Standard object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Subjects holds references to the objects the role applies to.
+	// ServiceAccount subjects without a namespace are bound in the organization namespace.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	// RoleRef references the ClusterRole to bind.
+	// If the RoleRef cannot be resolved, the Authorizer must return an error.
+	RoleRef rbacv1.RoleRef `json:"roleRef"`
+}
+
+// ClusterRoleBindingTemplateScopes describes the scopes the ClusterRoleBindingTemplate should be applied to
+type ClusterRoleBindingTemplateScopes struct {
+	OrganizationSelector ScopeSelector `json:"organizationSelector"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRoleBindingTemplate{}, &ClusterRoleBindingTemplateList{})
+}
@@ -28,14 +28,151 @@ import (
 type RoleBindingTemplateSpec struct {
 	Template RoleBindingTemplateResource `json:"template"`
 	Scopes   RoleBindingTemplateScopes   `json:"scopes"`
+
+	// ValidFrom is the time from which on the RoleBindings are created. They are created right away when unset.
+	// +optional
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil is the time at which the RoleBindings are removed. They are kept until the template is deleted when unset.
+	// +optional
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+
+	// AdoptionPolicy decides what happens to existing RoleBindings with the same name which are not managed by rbac-operator.
+	// +optional
+	// +kubebuilder:default=Fail
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Mode decides whether the RoleBindings are applied or the changes are only planned and recorded in the status.
+	// +optional
+	// +kubebuilder:default=Apply
+	Mode RoleBindingTemplateMode `json:"mode,omitempty"`
+
+	// MergeStrategy decides what happens when other RoleBindingTemplates render a RoleBinding with the same name into a namespace.
+	// +optional
+	// +kubebuilder:default=None
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
 }
 
+// MergeStrategy decides how a RoleBinding rendered by several RoleBindingTemplates is handled
+// +kubebuilder:validation:Enum=None;Union
+type MergeStrategy string
+
+const (
+	// MergeStrategyNone leaves RoleBindings owned by other templates untouched and reports them as collisions
+	MergeStrategyNone MergeStrategy = "None"
+	// MergeStrategyUnion binds the subjects of all templates using this strategy with the same roleRef
+	MergeStrategyUnion MergeStrategy = "Union"
+)
+
+// RoleBindingTemplateMode decides whether a RoleBindingTemplate is applied
+// +kubebuilder:validation:Enum=Apply;Plan
+type RoleBindingTemplateMode string
+
+const (
+	// RoleBindingTemplateModeApply creates, updates and deletes the RoleBindings
+	RoleBindingTemplateModeApply RoleBindingTemplateMode = "Apply"
+	// RoleBindingTemplateModePlan only records the changes which would be made in the status
+	RoleBindingTemplateModePlan RoleBindingTemplateMode = "Plan"
+)
+
+// AdoptionPolicy decides how existing RoleBindings not managed by rbac-operator are handled
+// +kubebuilder:validation:Enum=Adopt;Skip;Fail
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyAdopt overwrites the existing RoleBinding, which is managed by rbac-operator from then on
+	AdoptionPolicyAdopt AdoptionPolicy = "Adopt"
+	// AdoptionPolicySkip leaves the existing RoleBinding untouched
+	AdoptionPolicySkip AdoptionPolicy = "Skip"
+	// AdoptionPolicyFail leaves the existing RoleBinding untouched and reports the namespace as failed
+	AdoptionPolicyFail AdoptionPolicy = "Fail"
+)
+
 // RoleBindingTemplateStatus defines the observed state of RoleBindingTemplate
 type RoleBindingTemplateStatus struct {
 	// Namespaces contains a list of namespaces the RoleBinding is currently applied to
 	Namespaces []string `json:"namespaces,omitempty"`
+
+	// FailedNamespaces contains the namespaces in scope the RoleBinding could not be applied to
+	// +optional
+	FailedNamespaces []FailedNamespace `json:"failedNamespaces,omitempty"`
+
+	// PausedNamespaces contains the namespaces in scope which are left alone as they carry the paused annotation
+	// +optional
+	PausedNamespaces []string `json:"pausedNamespaces,omitempty"`
+
+	// UnmanagedRoleBindings contains the existing RoleBindings not managed by rbac-operator found during the last reconciliation
+	// +optional
+	UnmanagedRoleBindings []UnmanagedRoleBinding `json:"unmanagedRoleBindings,omitempty"`
+
+	// Plan contains the changes which would be made to the RoleBindings, only set in Plan mode
+	// +optional
+	Plan *RoleBindingTemplatePlan `json:"plan,omitempty"`
+
+	// ObservedGeneration is the generation of the RoleBindingTemplate the status was last computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the RoleBindingTemplate
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// FailedNamespace describes why a RoleBinding could not be applied to a namespace
+type FailedNamespace struct {
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message,omitempty"`
+}
+
+// RoleBindingTemplatePlan lists the RoleBindings which would be created, updated or deleted
+type RoleBindingTemplatePlan struct {
+	// +optional
+	Create []PlannedRoleBinding `json:"create,omitempty"`
+	// +optional
+	Update []PlannedRoleBinding `json:"update,omitempty"`
+	// +optional
+	Delete []PlannedRoleBinding `json:"delete,omitempty"`
+}
+
+// PlannedRoleBinding references a RoleBinding affected by a plan
+type PlannedRoleBinding struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
 }
 
+// UnmanagedRoleBinding describes how an existing RoleBinding not managed by rbac-operator was handled
+type UnmanagedRoleBinding struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Outcome   AdoptionOutcome `json:"outcome"`
+}
+
+// AdoptionOutcome is the result of applying the adoption policy to an existing RoleBinding
+type AdoptionOutcome string
+
+const (
+	// AdoptionOutcomeAdopted means the RoleBinding was overwritten
+	AdoptionOutcomeAdopted AdoptionOutcome = "Adopted"
+	// AdoptionOutcomeSkipped means the RoleBinding was left untouched
+	AdoptionOutcomeSkipped AdoptionOutcome = "Skipped"
+	// AdoptionOutcomeFailed means the RoleBinding was left untouched and the namespace reported as failed
+	AdoptionOutcomeFailed AdoptionOutcome = "Failed"
+)
+
+const (
+	// ConditionTypeReady is true when the RoleBinding is applied to all namespaces in scope
+	ConditionTypeReady = "Ready"
+	// ConditionTypeDegraded is true when the RoleBinding could not be applied to some namespaces in scope
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeScopeResolved is true when the namespaces in scope could be determined
+	ConditionTypeScopeResolved = "ScopeResolved"
+	// ConditionTypeActive is true while the current time is within validFrom and validUntil
+	ConditionTypeActive = "Active"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
@@ -68,16 +205,81 @@ type RoleBindingTemplateResource struct {
 	// +optional
 	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
 
+	// SubjectsFrom lists sources of groups which are bound in addition to the subjects.
+	// +optional
+	SubjectsFrom []SubjectsSource `json:"subjectsFrom,omitempty"`
+
 	// RoleRef can reference a Role in the current namespace or a ClusterRole in the global namespace.
 	// If the RoleRef cannot be resolved, the Authorizer must return an error.
 	RoleRef rbacv1.RoleRef `json:"roleRef"`
 }
 
+// SubjectsSource references a list of groups maintained outside of the template. Exactly one field must be set.
+type SubjectsSource struct {
+	// AccessGroups references the access groups rbac-operator is configured with by role.
+	// +optional
+	AccessGroups AccessGroupRole `json:"accessGroups,omitempty"`
+
+	// ConfigMapKeyRef references a ConfigMap key holding a list of groups separated by newlines or commas.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef,omitempty"`
+
+	// OrganizationAnnotation is the key of an annotation on the Organization a namespace belongs to,
+	// holding a comma separated list of groups.
+	// +optional
+	OrganizationAnnotation string `json:"organizationAnnotation,omitempty"`
+}
+
+// AccessGroupRole selects access groups from the rbac-operator configuration
+// +kubebuilder:validation:Enum=customerAdmins;customerReaders;giantswarmAdmins
+type AccessGroupRole string
+
+const (
+	// AccessGroupRoleCustomerAdmins selects the groups with write access to all customer resources
+	AccessGroupRoleCustomerAdmins AccessGroupRole = "customerAdmins"
+	// AccessGroupRoleCustomerReaders selects the groups with read access to all customer resources
+	AccessGroupRoleCustomerReaders AccessGroupRole = "customerReaders"
+	// AccessGroupRoleGiantswarmAdmins selects the groups with write access to all resources
+	AccessGroupRoleGiantswarmAdmins AccessGroupRole = "giantswarmAdmins"
+)
+
+// ConfigMapKeyReference references a key of a ConfigMap
+type ConfigMapKeyReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
 // RoleBindingTemplateScopes describes the scopes the RoleBindingTemplate should be applied to
 type RoleBindingTemplateScopes struct {
 	OrganizationSelector ScopeSelector `json:"organizationSelector"`
+
+	// NamespaceSelector restricts the namespaces in scope to the ones matching the given labels.
+	// +optional
+	NamespaceSelector ScopeSelector `json:"namespaceSelector,omitempty"`
+
+	// ClusterSelector restricts the cluster namespaces in scope to the ones matching the given labels.
+	// Organization namespaces are not affected by this selector.
+	// +optional
+	ClusterSelector ScopeSelector `json:"clusterSelector,omitempty"`
+
+	// Targets chooses the kinds of namespaces the RoleBindingTemplate is applied to.
+	// Defaults to both organization and cluster namespaces when empty.
+	// +optional
+	Targets []ScopeTarget `json:"targets,omitempty"`
 }
 
+// ScopeTarget is a kind of namespace belonging to an organization
+// +kubebuilder:validation:Enum=organizationNamespace;clusterNamespaces
+type ScopeTarget string
+
+const (
+	// ScopeTargetOrganizationNamespace targets the namespace of the organization
+	ScopeTargetOrganizationNamespace ScopeTarget = "organizationNamespace"
+	// ScopeTargetClusterNamespaces targets the namespaces of the clusters belonging to the organization
+	ScopeTargetClusterNamespaces ScopeTarget = "clusterNamespaces"
+)
+
 // ScopeSelector wraps a k8s label selector
 type ScopeSelector struct {
 	MatchLabels      map[string]string                 `json:"matchLabels,omitempty"`
@@ -0,0 +1,76 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoleTemplateSpec defines the desired state of RoleTemplate
+type RoleTemplateSpec struct {
+	Template RoleTemplateResource `json:"template"`
+	Scopes   RoleTemplateScopes   `json:"scopes"`
+}
+
+// RoleTemplateStatus defines the observed state of RoleTemplate
+type RoleTemplateStatus struct {
+	// Namespaces contains a list of namespaces the Role is currently applied to
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// RoleTemplate is the Schema for the roletemplates API
+type RoleTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleTemplateSpec   `json:"spec,omitempty"`
+	Status RoleTemplateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RoleTemplateList contains a list of RoleTemplate
+type RoleTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleTemplate `json:"items"`
+}
+
+// RoleTemplateResource describes the data needed to create a role from a template.
+type RoleTemplateResource struct {
+	// Standard object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Rules holds all the PolicyRules for this Role
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+}
+
+// RoleTemplateScopes describes the scopes the RoleTemplate should be applied to
+type RoleTemplateScopes struct {
+	OrganizationSelector ScopeSelector `json:"organizationSelector"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RoleTemplate{}, &RoleTemplateList{})
+}
@@ -22,11 +22,331 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/rbac/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroup) DeepCopyInto(out *AccessGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroup.
+func (in *AccessGroup) DeepCopy() *AccessGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroupBinding) DeepCopyInto(out *AccessGroupBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroupBinding.
+func (in *AccessGroupBinding) DeepCopy() *AccessGroupBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroupBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroupList) DeepCopyInto(out *AccessGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroupList.
+func (in *AccessGroupList) DeepCopy() *AccessGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroupSpec) DeepCopyInto(out *AccessGroupSpec) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]AccessGroupSubject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroupSpec.
+func (in *AccessGroupSpec) DeepCopy() *AccessGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroupStatus) DeepCopyInto(out *AccessGroupStatus) {
+	*out = *in
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]AccessGroupBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroupStatus.
+func (in *AccessGroupStatus) DeepCopy() *AccessGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGroupSubject) DeepCopyInto(out *AccessGroupSubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGroupSubject.
+func (in *AccessGroupSubject) DeepCopy() *AccessGroupSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGroupSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplate) DeepCopyInto(out *ClusterRoleBindingTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplate.
+func (in *ClusterRoleBindingTemplate) DeepCopy() *ClusterRoleBindingTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRoleBindingTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplateList) DeepCopyInto(out *ClusterRoleBindingTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRoleBindingTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplateList.
+func (in *ClusterRoleBindingTemplateList) DeepCopy() *ClusterRoleBindingTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRoleBindingTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplateResource) DeepCopyInto(out *ClusterRoleBindingTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	out.RoleRef = in.RoleRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplateResource.
+func (in *ClusterRoleBindingTemplateResource) DeepCopy() *ClusterRoleBindingTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplateScopes) DeepCopyInto(out *ClusterRoleBindingTemplateScopes) {
+	*out = *in
+	in.OrganizationSelector.DeepCopyInto(&out.OrganizationSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplateScopes.
+func (in *ClusterRoleBindingTemplateScopes) DeepCopy() *ClusterRoleBindingTemplateScopes {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplateScopes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplateSpec) DeepCopyInto(out *ClusterRoleBindingTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	in.Scopes.DeepCopyInto(&out.Scopes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplateSpec.
+func (in *ClusterRoleBindingTemplateSpec) DeepCopy() *ClusterRoleBindingTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBindingTemplateStatus) DeepCopyInto(out *ClusterRoleBindingTemplateStatus) {
+	*out = *in
+	if in.ClusterRoleBindings != nil {
+		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBindingTemplateStatus.
+func (in *ClusterRoleBindingTemplateStatus) DeepCopy() *ClusterRoleBindingTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBindingTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedNamespace) DeepCopyInto(out *FailedNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedNamespace.
+func (in *FailedNamespace) DeepCopy() *FailedNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedRoleBinding) DeepCopyInto(out *PlannedRoleBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedRoleBinding.
+func (in *PlannedRoleBinding) DeepCopy() *PlannedRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleBindingTemplate) DeepCopyInto(out *RoleBindingTemplate) {
 	*out = *in
@@ -86,15 +406,52 @@ func (in *RoleBindingTemplateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBindingTemplatePlan) DeepCopyInto(out *RoleBindingTemplatePlan) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = make([]PlannedRoleBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Update != nil {
+		in, out := &in.Update, &out.Update
+		*out = make([]PlannedRoleBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = make([]PlannedRoleBinding, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplatePlan.
+func (in *RoleBindingTemplatePlan) DeepCopy() *RoleBindingTemplatePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBindingTemplatePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleBindingTemplateResource) DeepCopyInto(out *RoleBindingTemplateResource) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	if in.Subjects != nil {
 		in, out := &in.Subjects, &out.Subjects
-		*out = make([]v1.Subject, len(*in))
+		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubjectsFrom != nil {
+		in, out := &in.SubjectsFrom, &out.SubjectsFrom
+		*out = make([]SubjectsSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.RoleRef = in.RoleRef
 }
 
@@ -112,6 +469,13 @@ func (in *RoleBindingTemplateResource) DeepCopy() *RoleBindingTemplateResource {
 func (in *RoleBindingTemplateScopes) DeepCopyInto(out *RoleBindingTemplateScopes) {
 	*out = *in
 	in.OrganizationSelector.DeepCopyInto(&out.OrganizationSelector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ScopeTarget, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplateScopes.
@@ -129,6 +493,14 @@ func (in *RoleBindingTemplateSpec) DeepCopyInto(out *RoleBindingTemplateSpec) {
 	*out = *in
 	in.Template.DeepCopyInto(&out.Template)
 	in.Scopes.DeepCopyInto(&out.Scopes)
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplateSpec.
@@ -149,6 +521,33 @@ func (in *RoleBindingTemplateStatus) DeepCopyInto(out *RoleBindingTemplateStatus
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedNamespaces != nil {
+		in, out := &in.FailedNamespaces, &out.FailedNamespaces
+		*out = make([]FailedNamespace, len(*in))
+		copy(*out, *in)
+	}
+	if in.PausedNamespaces != nil {
+		in, out := &in.PausedNamespaces, &out.PausedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnmanagedRoleBindings != nil {
+		in, out := &in.UnmanagedRoleBindings, &out.UnmanagedRoleBindings
+		*out = make([]UnmanagedRoleBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(RoleBindingTemplatePlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingTemplateStatus.
@@ -161,6 +560,141 @@ func (in *RoleBindingTemplateStatus) DeepCopy() *RoleBindingTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplate) DeepCopyInto(out *RoleTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplate.
+func (in *RoleTemplate) DeepCopy() *RoleTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateList) DeepCopyInto(out *RoleTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoleTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateList.
+func (in *RoleTemplateList) DeepCopy() *RoleTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateResource) DeepCopyInto(out *RoleTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateResource.
+func (in *RoleTemplateResource) DeepCopy() *RoleTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateScopes) DeepCopyInto(out *RoleTemplateScopes) {
+	*out = *in
+	in.OrganizationSelector.DeepCopyInto(&out.OrganizationSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateScopes.
+func (in *RoleTemplateScopes) DeepCopy() *RoleTemplateScopes {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateScopes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateSpec) DeepCopyInto(out *RoleTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	in.Scopes.DeepCopyInto(&out.Scopes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateSpec.
+func (in *RoleTemplateSpec) DeepCopy() *RoleTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateStatus) DeepCopyInto(out *RoleTemplateStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateStatus.
+func (in *RoleTemplateStatus) DeepCopy() *RoleTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScopeSelector) DeepCopyInto(out *ScopeSelector) {
 	*out = *in
@@ -173,7 +707,7 @@ func (in *ScopeSelector) DeepCopyInto(out *ScopeSelector) {
 	}
 	if in.MatchExpressions != nil {
 		in, out := &in.MatchExpressions, &out.MatchExpressions
-		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -189,3 +723,38 @@ func (in *ScopeSelector) DeepCopy() *ScopeSelector {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectsSource) DeepCopyInto(out *SubjectsSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectsSource.
+func (in *SubjectsSource) DeepCopy() *SubjectsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmanagedRoleBinding) DeepCopyInto(out *UnmanagedRoleBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmanagedRoleBinding.
+func (in *UnmanagedRoleBinding) DeepCopy() *UnmanagedRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmanagedRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
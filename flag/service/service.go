@@ -2,6 +2,8 @@ package service
 
 import (
 	"github.com/giantswarm/operatorkit/v7/pkg/flag/service/kubernetes"
+
+	"github.com/giantswarm/rbac-operator/flag/service/webhook"
 )
 
 // Service is an intermediate data structure for command line configuration flags.
@@ -13,7 +15,11 @@ type Service struct {
 
 	AccessGroups string
 
+	ProtectedNamespaces string
+
 	CrossplaneBindTriggeringClusterRoleName string
 
 	Provider string
+
+	Webhook webhook.Webhook
 }
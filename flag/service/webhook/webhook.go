@@ -0,0 +1,14 @@
+package webhook
+
+// Webhook is a data structure to hold the admission webhook command line
+// configuration flags.
+type Webhook struct {
+	Address string
+	TLS     TLS
+}
+
+// TLS holds the serving certificate of the admission webhook.
+type TLS struct {
+	CrtFile string
+	KeyFile string
+}
@@ -32,3 +32,21 @@ Selector labels
 app.kubernetes.io/name: {{ include "name" . | quote }}
 app.kubernetes.io/instance: {{ .Release.Name | quote }}
 {{- end -}}
+
+{{/*
+Access group list item. A string is the name of a group, an object has a name,
+a kind (Group, User or ServiceAccount) and, for ServiceAccounts, a namespace.
+*/}}
+{{- define "accessGroup" -}}
+{{- if kindIs "string" . -}}
+- name: {{ . }}
+{{- else -}}
+- name: {{ .name }}
+{{- with .kind }}
+  kind: {{ . }}
+{{- end }}
+{{- with .namespace }}
+  namespace: {{ . }}
+{{- end }}
+{{- end -}}
+{{- end -}}
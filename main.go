@@ -15,6 +15,7 @@ import (
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/server"
 	"github.com/giantswarm/rbac-operator/service"
+	"github.com/giantswarm/rbac-operator/service/render"
 )
 
 var (
@@ -76,6 +77,7 @@ func mainE(ctx context.Context) error {
 				Logger:  logger,
 				Service: newService,
 
+				Flag:  f,
 				Viper: v,
 			}
 
@@ -110,18 +112,24 @@ func mainE(ctx context.Context) error {
 
 	daemonCommand := newCommand.DaemonCommand().CobraCommand()
 
-	daemonCommand.PersistentFlags().String(f.Service.WriteAllCustomerGroup, "", "Customer identity provider admin group.")
-	daemonCommand.PersistentFlags().String(f.Service.WriteAllGiantswarmGroup, "", "Giant Swarm identity provider admin group.")
+	daemonCommand.PersistentFlags().String(f.Service.WriteAllCustomerGroup, "", "Customer identity provider admin group. Deprecated, use AccessGroup resources instead.")
+	daemonCommand.PersistentFlags().String(f.Service.WriteAllGiantswarmGroup, "", "Giant Swarm identity provider admin group. Deprecated, use the Giant Swarm admin access groups instead.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.Address, "http://127.0.0.1:6443", "Address used to connect to Kubernetes. When empty in-cluster config is created.")
 	daemonCommand.PersistentFlags().Bool(f.Service.Kubernetes.InCluster, false, "Whether to use the in-cluster config to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.KubeConfig, "", "KubeConfig used to connect to Kubernetes. When empty other settings are used.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CAFile, "", "Certificate authority file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CrtFile, "", "Certificate file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.KeyFile, "", "Key file path to use to authenticate with Kubernetes.")
-	daemonCommand.PersistentFlags().String(f.Service.AccessGroups, "", "Groups to be granted access to resources in the cluster")
+	daemonCommand.PersistentFlags().String(f.Service.AccessGroups, "", "Groups to be granted access to resources in the cluster, in addition to the ones declared using AccessGroup resources")
+	daemonCommand.PersistentFlags().String(f.Service.ProtectedNamespaces, "", "Namespaces in which only the listed subjects may be bound. Defaults to org-giantswarm when empty.")
 	daemonCommand.PersistentFlags().String(f.Service.CrossplaneBindTriggeringClusterRoleName, "crossplane-edit",
 		"ClusterRole name created by rbac-manager from crossplane that triggers binding to customer's admin group.")
 	daemonCommand.PersistentFlags().String(f.Service.Provider, "", "Infrastructure provider (e.g. capa, capz, capv).")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.Address, "", "Address the admission webhook listens on. When empty the webhook is disabled.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.TLS.CrtFile, "", "Certificate file path used to serve the admission webhook.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.TLS.KeyFile, "", "Key file path used to serve the admission webhook.")
+
+	newCommand.CobraCommand().AddCommand(render.NewCommand())
 
 	err = newCommand.CobraCommand().Execute()
 	if err != nil {
@@ -3,6 +3,24 @@ package annotation
 const (
 	// LegacyOrganization Annotation, used on organizations that were migrated previously
 	LegacyOrganization = "ui.giantswarm.io/original-organization-name"
+
+	// Paused Annotation, when set to "true" rbac-operator leaves the object, or everything in the namespace, alone
+	Paused = "rbac.giantswarm.io/paused"
+
+	// OrganizationAdminGroups Annotation, on an Organization or its namespace, groups bound to cluster-admin in the organization namespace
+	OrganizationAdminGroups = "rbac.giantswarm.io/admin-groups"
+
+	// OrganizationReaderGroups Annotation, on an Organization or its namespace, groups bound to read-all in the organization namespace
+	OrganizationReaderGroups = "rbac.giantswarm.io/reader-groups"
+
+	// OrganizationGroupsOnly Annotation, when set to "true" only the organization groups are bound in the organization namespace
+	OrganizationGroupsOnly = "rbac.giantswarm.io/organization-groups-only"
+
+	// Resync Annotation, set by rbac-operator to the time it requeued the object, e.g. after the access groups changed
+	Resync = "rbac.giantswarm.io/resync"
+
+	// RoleBindingTemplateSubjects Annotation, subjects each RoleBindingTemplate contributed to a merged RoleBinding
+	RoleBindingTemplateSubjects = "auth.giantswarm.io/rolebindingtemplate-subjects"
 )
 
 type AnnotationsGetter interface {
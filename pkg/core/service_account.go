@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/rbac-operator/pkg/base"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 )
 
 func ServiceAccountNeedsUpdate(desiredSA, existingSA *corev1.ServiceAccount) bool {
@@ -39,6 +40,13 @@ func ServiceAccountNeedsUpdate(desiredSA, existingSA *corev1.ServiceAccount) boo
 }
 
 func CreateOrUpdateServiceAccount(c base.K8sClientWithLogging, ctx context.Context, namespace string, desiredSA *corev1.ServiceAccount) error {
+	skip, err := pause.SkipInNamespace(c, ctx, "ServiceAccount", namespace, desiredSA.Name)
+	if err != nil {
+		return microerror.Mask(err)
+	} else if skip {
+		return nil
+	}
+
 	existingSA, err := c.K8sClient().CoreV1().ServiceAccounts(namespace).Get(ctx, desiredSA.Name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("creating serviceaccount %#q in namespace %s", desiredSA.Name, namespace))
@@ -54,6 +62,8 @@ func CreateOrUpdateServiceAccount(c base.K8sClientWithLogging, ctx context.Conte
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingSA) {
+		pause.Skip(c.Logger(), ctx, "ServiceAccount", namespace, desiredSA.Name)
 	} else if ServiceAccountNeedsUpdate(existingSA, desiredSA) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating serviceaccount %#q in namespace %s", desiredSA.Name, namespace))
 
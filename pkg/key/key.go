@@ -68,10 +68,6 @@ func IsOrgNamespace(ns string) bool {
 	return strings.HasPrefix(ns, "org-")
 }
 
-func IsProtectedNamespace(ns string) bool {
-	return ns == "org-giantswarm"
-}
-
 func Organization(getter rbacLabel.LabelsGetter) string {
 	return getter.GetLabels()[label.Organization]
 }
@@ -113,6 +109,12 @@ func OrganizationWriteClusterNamespaceRoleBindingName(organization string) strin
 	return fmt.Sprintf("cluster-ns-organization-%s-write", organization)
 }
 
+func AccessTierBindingName(tier, clusterRole string) string {
+	return fmt.Sprintf("access-tier-%s-%s", tier, clusterRole)
+}
+
+// ReadAllCustomerGroupClusterRoleBindingName is also the name of the RoleBindings
+// of the reader groups in the organization namespaces.
 func ReadAllCustomerGroupClusterRoleBindingName() string {
 	return fmt.Sprintf("%s-customer-group", DefaultReadAllPermissionsName)
 }
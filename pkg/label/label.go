@@ -3,6 +3,18 @@ package label
 const (
 	// LegacyCustomer Labels, used in legacy cluster namespaces
 	LegacyCustomer = "customer"
+
+	// AccessTier Label, name of the access tier a RoleBinding or ClusterRoleBinding binds
+	AccessTier = "rbac.giantswarm.io/access-tier"
+
+	// RoleBindingTemplate Label, name of the RoleBindingTemplate a RoleBinding was generated from
+	RoleBindingTemplate = "auth.giantswarm.io/rolebindingtemplate"
+	// RoleBindingTemplateUID Label, UID of the RoleBindingTemplate a RoleBinding was generated from
+	RoleBindingTemplateUID = "auth.giantswarm.io/rolebindingtemplate-uid"
+	// RoleBindingTemplateMerged Label, set on RoleBindings whose subjects are merged from several RoleBindingTemplates
+	RoleBindingTemplateMerged = "auth.giantswarm.io/rolebindingtemplate-merged"
+	// SubjectsSource Label, marks ConfigMaps referenced in subjectsFrom of RoleBindingTemplates so that changes requeue them
+	SubjectsSource = "auth.giantswarm.io/subjects-source"
 )
 
 type LabelsGetter interface {
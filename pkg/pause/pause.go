@@ -0,0 +1,90 @@
+// Package pause implements the rbac.giantswarm.io/paused annotation. Objects
+// carrying it, and objects in namespaces carrying it, are neither updated nor
+// deleted by rbac-operator, so that they can be edited by hand during
+// incidents.
+package pause
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+	"github.com/giantswarm/rbac-operator/pkg/base"
+)
+
+// skipped counts the skipped changes by kind, exposed by the PausedSkipped
+// collector.
+var skipped = struct {
+	sync.Mutex
+	total map[string]float64
+}{
+	total: map[string]float64{},
+}
+
+// SkippedTotal returns the number of skipped changes to paused objects since
+// the operator started, by kind.
+func SkippedTotal() map[string]float64 {
+	skipped.Lock()
+	defer skipped.Unlock()
+
+	total := make(map[string]float64, len(skipped.total))
+	for kind, count := range skipped.total {
+		total[kind] = count
+	}
+
+	return total
+}
+
+// IsPaused returns true when the object carries the paused annotation with
+// the value "true".
+func IsPaused(getter annotation.AnnotationsGetter) bool {
+	return getter.GetAnnotations()[annotation.Paused] == "true"
+}
+
+// IsNamespacePaused returns true when the given namespace exists and is
+// paused.
+func IsNamespacePaused(c base.K8sClientWithLogging, ctx context.Context, namespace string) (bool, error) {
+	ns, err := c.K8sClient().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return IsPaused(ns), nil
+}
+
+// Skip logs and counts that a change to the given object was skipped because
+// the object or its namespace is paused.
+func Skip(logger micrologger.Logger, ctx context.Context, kind, namespace, name string) {
+	if namespace == "" {
+		logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("%s %#q is paused, skipping", kind, name))
+	} else {
+		logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("%s %#q in namespace %s is paused, skipping", kind, name, namespace))
+	}
+
+	skipped.Lock()
+	skipped.total[kind]++
+	skipped.Unlock()
+}
+
+// SkipInNamespace returns true when the given namespace is paused, in which
+// case the skipped object is logged and counted.
+func SkipInNamespace(c base.K8sClientWithLogging, ctx context.Context, kind, namespace, name string) (bool, error) {
+	paused, err := IsNamespacePaused(c, ctx, namespace)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	if paused {
+		Skip(c.Logger(), ctx, kind, namespace, name)
+	}
+
+	return paused, nil
+}
@@ -0,0 +1,106 @@
+package pause
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+type testClient struct {
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+}
+
+func (c testClient) K8sClient() kubernetes.Interface { return c.k8sClient }
+func (c testClient) Logger() micrologger.Logger      { return c.logger }
+
+func newNamespace(name string, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: annotations,
+		},
+	}
+}
+
+func Test_SkipInNamespace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		namespace    string
+		objects      []runtime.Object
+		expectedSkip bool
+	}{
+		{
+			name:         "case 0: paused namespace is skipped",
+			namespace:    "org-acme",
+			objects:      []runtime.Object{newNamespace("org-acme", map[string]string{annotation.Paused: "true"})},
+			expectedSkip: true,
+		},
+		{
+			name:         "case 1: namespace without annotation is not skipped",
+			namespace:    "org-acme",
+			objects:      []runtime.Object{newNamespace("org-acme", nil)},
+			expectedSkip: false,
+		},
+		{
+			name:         "case 2: annotation values other than true are ignored",
+			namespace:    "org-acme",
+			objects:      []runtime.Object{newNamespace("org-acme", map[string]string{annotation.Paused: "false"})},
+			expectedSkip: false,
+		},
+		{
+			name:         "case 3: missing namespace is not skipped",
+			namespace:    "org-acme",
+			expectedSkip: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testClient{
+				k8sClient: clientgofake.NewSimpleClientset(tc.objects...),
+				logger:    microloggertest.New(),
+			}
+
+			skip, err := SkipInNamespace(c, context.Background(), "RoleBinding", tc.namespace, "test")
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+			if skip != tc.expectedSkip {
+				t.Fatalf("expected skip to be %t, got %t", tc.expectedSkip, skip)
+			}
+		})
+	}
+}
+
+func Test_SkippedTotal(t *testing.T) {
+	before := SkippedTotal()
+
+	logger := microloggertest.New()
+	Skip(logger, context.Background(), "Role", "org-acme", "test")
+	Skip(logger, context.Background(), "Role", "org-acme", "test")
+	Skip(logger, context.Background(), "ClusterRole", "", "test")
+
+	after := SkippedTotal()
+	if after["Role"]-before["Role"] != 2 {
+		t.Fatalf("expected 2 skipped Roles, got %v", after["Role"]-before["Role"])
+	}
+	if after["ClusterRole"]-before["ClusterRole"] != 1 {
+		t.Fatalf("expected 1 skipped ClusterRole, got %v", after["ClusterRole"]-before["ClusterRole"])
+	}
+
+	// the returned counts are a copy
+	after["Role"] = 0
+	if SkippedTotal()["Role"] == 0 {
+		t.Fatal("expected the skipped counts not to be changed through the returned map")
+	}
+}
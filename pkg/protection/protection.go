@@ -0,0 +1,144 @@
+// Package protection implements the policy deciding which subjects may be
+// bound in protected namespaces, e.g. the namespaces of internal organizations.
+package protection
+
+import (
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+)
+
+const (
+	// DefaultProtectedNamespace is protected unless another policy is configured
+	DefaultProtectedNamespace = "org-giantswarm"
+)
+
+// Policy lists the protected namespaces. Subjects not allowed in a protected
+// namespace are removed from the bindings written to it.
+type Policy struct {
+	Namespaces []Namespace
+}
+
+// Namespace is a protected namespace along with the subjects which may be bound in it.
+type Namespace struct {
+	Name            string
+	AllowedSubjects []AllowedSubject
+}
+
+// AllowedSubject allows subjects of the given kind. For ServiceAccounts, the
+// namespaces they may come from are listed in Namespaces, SameNamespace allows
+// ServiceAccounts from the protected namespace itself.
+type AllowedSubject struct {
+	Kind          string
+	Namespaces    []string
+	SameNamespace bool
+}
+
+// DefaultPolicy only allows ServiceAccounts from the flux namespace or the
+// namespace itself to be bound in org-giantswarm.
+func DefaultPolicy() Policy {
+	return Policy{
+		Namespaces: []Namespace{
+			{
+				Name: DefaultProtectedNamespace,
+				AllowedSubjects: []AllowedSubject{
+					{
+						Kind:          rbacv1.ServiceAccountKind,
+						Namespaces:    []string{pkgkey.FluxNamespaceName},
+						SameNamespace: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// OrDefault returns the policy, or the default policy if it protects no
+// namespaces, e.g. because none were configured.
+func (p Policy) OrDefault() Policy {
+	if len(p.Namespaces) == 0 {
+		return DefaultPolicy()
+	}
+	return p
+}
+
+// Validate checks the policy for missing names and unsupported subject kinds.
+func (p Policy) Validate() error {
+	seen := map[string]bool{}
+	for i, namespace := range p.Namespaces {
+		if namespace.Name == "" {
+			return microerror.Maskf(invalidConfigError, "protected namespace %d must have a name", i)
+		}
+		if seen[namespace.Name] {
+			return microerror.Maskf(invalidConfigError, "protected namespace %s is listed more than once", namespace.Name)
+		}
+		seen[namespace.Name] = true
+
+		for _, allowed := range namespace.AllowedSubjects {
+			switch allowed.Kind {
+			case rbacv1.UserKind, rbacv1.GroupKind:
+				if len(allowed.Namespaces) > 0 || allowed.SameNamespace {
+					return microerror.Maskf(invalidConfigError, "protected namespace %s allows subjects of kind %s by namespace, which only applies to %s", namespace.Name, allowed.Kind, rbacv1.ServiceAccountKind)
+				}
+			case rbacv1.ServiceAccountKind:
+			default:
+				return microerror.Maskf(invalidConfigError, "protected namespace %s allows unsupported subject kind %#q", namespace.Name, allowed.Kind)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsProtected reports whether the namespace is protected.
+func (p Policy) IsProtected(namespace string) bool {
+	_, ok := p.get(namespace)
+	return ok
+}
+
+// FilterSubjects removes the subjects not allowed in the namespace. The subjects
+// are returned unchanged if the namespace is not protected.
+func (p Policy) FilterSubjects(namespace string, subjects []rbacv1.Subject) []rbacv1.Subject {
+	protected, ok := p.get(namespace)
+	if !ok {
+		return subjects
+	}
+
+	var allowedSubjects []rbacv1.Subject
+	for _, subject := range subjects {
+		if protected.allows(subject) {
+			allowedSubjects = append(allowedSubjects, subject)
+		}
+	}
+	return allowedSubjects
+}
+
+func (p Policy) get(namespace string) (Namespace, bool) {
+	for _, protected := range p.Namespaces {
+		if protected.Name == namespace {
+			return protected, true
+		}
+	}
+	return Namespace{}, false
+}
+
+func (n Namespace) allows(subject rbacv1.Subject) bool {
+	for _, allowed := range n.AllowedSubjects {
+		if allowed.Kind != subject.Kind {
+			continue
+		}
+		if subject.Kind != rbacv1.ServiceAccountKind {
+			return true
+		}
+		if allowed.SameNamespace && subject.Namespace == n.Name {
+			return true
+		}
+		for _, namespace := range allowed.Namespaces {
+			if subject.Namespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package protection
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestFilterSubjects(t *testing.T) {
+	subjects := []rbacv1.Subject{
+		{Kind: "Group", Name: "customer:admins"},
+		{Kind: "User", Name: "jane"},
+		{Kind: "ServiceAccount", Name: "automation", Namespace: "org-internal"},
+		{Kind: "ServiceAccount", Name: "kustomize-controller", Namespace: "flux-system"},
+		{Kind: "ServiceAccount", Name: "automation", Namespace: "org-example"},
+	}
+
+	testCases := []struct {
+		Name      string
+		Policy    Policy
+		Namespace string
+
+		expectedSubjects []rbacv1.Subject
+	}{
+		{
+			Name:             "case0: keep subjects in unprotected namespace",
+			Policy:           DefaultPolicy(),
+			Namespace:        "org-internal",
+			expectedSubjects: subjects,
+		},
+		{
+			Name:      "case1: default policy only allows service accounts from flux and the namespace itself",
+			Policy:    DefaultPolicy(),
+			Namespace: "org-giantswarm",
+			expectedSubjects: []rbacv1.Subject{
+				{Kind: "ServiceAccount", Name: "kustomize-controller", Namespace: "flux-system"},
+			},
+		},
+		{
+			Name: "case2: allow groups and service accounts from the same namespace",
+			Policy: Policy{
+				Namespaces: []Namespace{
+					{
+						Name: "org-internal",
+						AllowedSubjects: []AllowedSubject{
+							{Kind: "Group"},
+							{Kind: "ServiceAccount", SameNamespace: true},
+						},
+					},
+				},
+			},
+			Namespace: "org-internal",
+			expectedSubjects: []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:admins"},
+				{Kind: "ServiceAccount", Name: "automation", Namespace: "org-internal"},
+			},
+		},
+		{
+			Name: "case3: allow nothing",
+			Policy: Policy{
+				Namespaces: []Namespace{
+					{Name: "org-internal"},
+				},
+			},
+			Namespace: "org-internal",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := tc.Policy.FilterSubjects(tc.Namespace, subjects)
+			if !reflect.DeepEqual(tc.expectedSubjects, result) {
+				t.Fatalf("Expected subjects %v, got %v", tc.expectedSubjects, result)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Policy Policy
+
+		expectError bool
+	}{
+		{
+			Name:   "case0: default policy is valid",
+			Policy: DefaultPolicy(),
+		},
+		{
+			Name: "case1: missing name",
+			Policy: Policy{
+				Namespaces: []Namespace{{}},
+			},
+			expectError: true,
+		},
+		{
+			Name: "case2: duplicate namespace",
+			Policy: Policy{
+				Namespaces: []Namespace{{Name: "org-internal"}, {Name: "org-internal"}},
+			},
+			expectError: true,
+		},
+		{
+			Name: "case3: unsupported kind",
+			Policy: Policy{
+				Namespaces: []Namespace{
+					{Name: "org-internal", AllowedSubjects: []AllowedSubject{{Kind: "Team"}}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			Name: "case4: namespaces for groups",
+			Policy: Policy{
+				Namespaces: []Namespace{
+					{Name: "org-internal", AllowedSubjects: []AllowedSubject{{Kind: "Group", Namespaces: []string{"flux-system"}}}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Policy.Validate()
+			if tc.expectError && !IsInvalidConfig(err) {
+				t.Fatalf("Expected invalid config error, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+		})
+	}
+}
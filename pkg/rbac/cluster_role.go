@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/rbac-operator/pkg/base"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 )
 
 func ClusterRoleNeedsUpdate(desiredClusterRole, existingClusterRole *rbacv1.ClusterRole) bool {
@@ -42,6 +43,8 @@ func CreateOrUpdateClusterRole(c base.K8sClientWithLogging, ctx context.Context,
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingClusterRole) {
+		pause.Skip(c.Logger(), ctx, "ClusterRole", "", clusterRole.Name)
 	} else if ClusterRoleNeedsUpdate(clusterRole, existingClusterRole) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating clusterrole %#q", clusterRole.Name))
 		_, err := c.K8sClient().RbacV1().ClusterRoles().Update(ctx, clusterRole, metav1.UpdateOptions{})
@@ -55,13 +58,13 @@ func CreateOrUpdateClusterRole(c base.K8sClientWithLogging, ctx context.Context,
 }
 
 func DeleteClusterRole(c base.K8sClientWithLogging, ctx context.Context, clusterRole string) error {
-	var err error
-
-	_, err = c.K8sClient().RbacV1().ClusterRoles().Get(ctx, clusterRole, metav1.GetOptions{})
+	existingClusterRole, err := c.K8sClient().RbacV1().ClusterRoles().Get(ctx, clusterRole, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// nothing to be done
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingClusterRole) {
+		pause.Skip(c.Logger(), ctx, "ClusterRole", "", clusterRole)
 	} else {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Deleting ClusterRole %s", clusterRole))
 
@@ -7,11 +7,14 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/rbac-operator/pkg/base"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+	"github.com/giantswarm/rbac-operator/pkg/project"
 )
 
 // ClusterRoleBindingNeedsUpdate ClusterRoleBinding needs an update with the list of subjects has changed
@@ -43,6 +46,8 @@ func CreateOrUpdateClusterRoleBinding(c base.K8sClientWithLogging, ctx context.C
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingClusterRoleBinding) {
+		pause.Skip(c.Logger(), ctx, "ClusterRoleBinding", "", clusterRoleBinding.Name)
 	} else if ClusterRoleBindingNeedsUpdate(clusterRoleBinding, existingClusterRoleBinding) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating clusterrolebinding %#q", clusterRoleBinding.Name))
 
@@ -57,13 +62,13 @@ func CreateOrUpdateClusterRoleBinding(c base.K8sClientWithLogging, ctx context.C
 }
 
 func DeleteClusterRoleBinding(c base.K8sClientWithLogging, ctx context.Context, clusterRoleBinding string) error {
-	var err error
-
-	_, err = c.K8sClient().RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding, metav1.GetOptions{})
+	existingClusterRoleBinding, err := c.K8sClient().RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// nothing to be done
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingClusterRoleBinding) {
+		pause.Skip(c.Logger(), ctx, "ClusterRoleBinding", "", clusterRoleBinding)
 	} else {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Deleting ClusterRoleBinding %s", clusterRoleBinding))
 
@@ -78,3 +83,20 @@ func DeleteClusterRoleBinding(c base.K8sClientWithLogging, ctx context.Context,
 
 	return nil
 }
+
+// DeleteManagedClusterRoleBinding deletes the ClusterRoleBinding only if it is
+// managed by rbac-operator, e.g. once none of its subjects are to be bound anymore.
+func DeleteManagedClusterRoleBinding(c base.K8sClientWithLogging, ctx context.Context, clusterRoleBinding string) error {
+	existingClusterRoleBinding, err := c.K8sClient().RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if existingClusterRoleBinding.Labels[label.ManagedBy] != project.Name() {
+		return nil
+	}
+
+	return DeleteClusterRoleBinding(c, ctx, clusterRoleBinding)
+}
@@ -0,0 +1,158 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+)
+
+type testClient struct {
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+}
+
+func (c testClient) K8sClient() kubernetes.Interface { return c.k8sClient }
+func (c testClient) Logger() micrologger.Logger      { return c.logger }
+
+var paused = map[string]string{annotation.Paused: "true"}
+
+func Test_PausedObjectsAreLeftAlone(t *testing.T) {
+	handEdited := []rbacv1.Subject{{Kind: "Group", Name: "incident-responders"}}
+	desired := []rbacv1.Subject{{Kind: "Group", Name: "customer:admins"}}
+
+	testCases := []struct {
+		name     string
+		objects  []runtime.Object
+		run      func(c testClient) error
+		expected func(t *testing.T, c testClient)
+	}{
+		{
+			name: "case 0: paused ClusterRoleBinding keeps its subjects",
+			objects: []runtime.Object{
+				&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "crb", Annotations: paused}, Subjects: handEdited},
+			},
+			run: func(c testClient) error {
+				return CreateOrUpdateClusterRoleBinding(c, context.Background(), &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "crb"}, Subjects: desired})
+			},
+			expected: func(t *testing.T, c testClient) {
+				crb, err := c.k8sClient.RbacV1().ClusterRoleBindings().Get(context.Background(), "crb", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("received unexpected error %s", err)
+				}
+				if crb.Subjects[0].Name != "incident-responders" {
+					t.Fatalf("expected subjects to be kept, got %v", crb.Subjects)
+				}
+			},
+		},
+		{
+			name: "case 1: paused ClusterRole is not deleted",
+			objects: []runtime.Object{
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cr", Annotations: paused}},
+			},
+			run: func(c testClient) error {
+				return DeleteClusterRole(c, context.Background(), "cr")
+			},
+			expected: func(t *testing.T, c testClient) {
+				_, err := c.k8sClient.RbacV1().ClusterRoles().Get(context.Background(), "cr", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("expected ClusterRole to be kept, got %s", err)
+				}
+			},
+		},
+		{
+			name: "case 2: RoleBinding in paused namespace keeps its subjects",
+			objects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme", Annotations: paused}},
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "org-acme"}, Subjects: handEdited},
+			},
+			run: func(c testClient) error {
+				return CreateOrUpdateRoleBinding(c, context.Background(), protection.DefaultPolicy(), "org-acme", &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "org-acme"}, Subjects: desired})
+			},
+			expected: func(t *testing.T, c testClient) {
+				rb, err := c.k8sClient.RbacV1().RoleBindings("org-acme").Get(context.Background(), "rb", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("received unexpected error %s", err)
+				}
+				if rb.Subjects[0].Name != "incident-responders" {
+					t.Fatalf("expected subjects to be kept, got %v", rb.Subjects)
+				}
+			},
+		},
+		{
+			name: "case 3: Role is not created in paused namespace",
+			objects: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme", Annotations: paused}},
+			},
+			run: func(c testClient) error {
+				return CreateOrUpdateRole(c, context.Background(), "org-acme", &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "org-acme"}})
+			},
+			expected: func(t *testing.T, c testClient) {
+				_, err := c.k8sClient.RbacV1().Roles("org-acme").Get(context.Background(), "role", metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected Role not to be created, got %v", err)
+				}
+			},
+		},
+		{
+			name: "case 4: paused RoleBinding is not deleted",
+			objects: []runtime.Object{
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "org-acme", Annotations: paused}},
+			},
+			run: func(c testClient) error {
+				return DeleteRoleBinding(c, context.Background(), "org-acme", "rb")
+			},
+			expected: func(t *testing.T, c testClient) {
+				_, err := c.k8sClient.RbacV1().RoleBindings("org-acme").Get(context.Background(), "rb", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("expected RoleBinding to be kept, got %s", err)
+				}
+			},
+		},
+		{
+			name: "case 5: unpaused RoleBinding is updated",
+			objects: []runtime.Object{
+				&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "org-acme"}, Subjects: handEdited},
+			},
+			run: func(c testClient) error {
+				return CreateOrUpdateRoleBinding(c, context.Background(), protection.DefaultPolicy(), "org-acme", &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "org-acme"}, Subjects: desired})
+			},
+			expected: func(t *testing.T, c testClient) {
+				rb, err := c.k8sClient.RbacV1().RoleBindings("org-acme").Get(context.Background(), "rb", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("received unexpected error %s", err)
+				}
+				if rb.Subjects[0].Name != "customer:admins" {
+					t.Fatalf("expected subjects to be updated, got %v", rb.Subjects)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testClient{
+				k8sClient: clientgofake.NewSimpleClientset(tc.objects...),
+				logger:    microloggertest.New(),
+			}
+
+			err := tc.run(c)
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+
+			tc.expected(t, c)
+		})
+	}
+}
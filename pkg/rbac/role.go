@@ -8,10 +8,13 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/giantswarm/rbac-operator/pkg/base"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+	"github.com/giantswarm/rbac-operator/pkg/project"
 )
 
 // RoleNeedsUpdate Role needs an update if the rules have changed
@@ -28,6 +31,13 @@ func RoleNeedsUpdate(desiredRole, existingRole *rbacv1.Role) bool {
 }
 
 func CreateOrUpdateRole(c base.K8sClientWithLogging, ctx context.Context, namespace string, role *rbacv1.Role) error {
+	skip, err := pause.SkipInNamespace(c, ctx, "Role", namespace, role.Name)
+	if err != nil {
+		return microerror.Mask(err)
+	} else if skip {
+		return nil
+	}
+
 	existingRole, err := c.K8sClient().RbacV1().Roles(namespace).Get(ctx, role.Name, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Creating Role %#q in namespace %s.", role.Name, namespace))
@@ -43,6 +53,8 @@ func CreateOrUpdateRole(c base.K8sClientWithLogging, ctx context.Context, namesp
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingRole) {
+		pause.Skip(c.Logger(), ctx, "Role", namespace, role.Name)
 	} else if RoleNeedsUpdate(role, existingRole) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Updating Role %#q in namespace %s.", role.Name, namespace))
 		_, err := c.K8sClient().RbacV1().Roles(namespace).Update(ctx, role, metav1.UpdateOptions{})
@@ -57,13 +69,20 @@ func CreateOrUpdateRole(c base.K8sClientWithLogging, ctx context.Context, namesp
 }
 
 func DeleteRole(c base.K8sClientWithLogging, ctx context.Context, namespace string, role string) error {
-	var err error
+	skip, err := pause.SkipInNamespace(c, ctx, "Role", namespace, role)
+	if err != nil {
+		return microerror.Mask(err)
+	} else if skip {
+		return nil
+	}
 
-	_, err = c.K8sClient().RbacV1().Roles(namespace).Get(ctx, role, metav1.GetOptions{})
+	existingRole, err := c.K8sClient().RbacV1().Roles(namespace).Get(ctx, role, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// nothing to be done
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingRole) {
+		pause.Skip(c.Logger(), ctx, "Role", namespace, role)
 	} else {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Deleting Role %#q in namespace %s.", role, namespace))
 
@@ -77,3 +96,20 @@ func DeleteRole(c base.K8sClientWithLogging, ctx context.Context, namespace stri
 	}
 	return nil
 }
+
+// DeleteManagedRole deletes the Role only if it is managed by rbac-operator,
+// e.g. once it is no longer rendered into the namespace.
+func DeleteManagedRole(c base.K8sClientWithLogging, ctx context.Context, namespace string, role string) error {
+	existingRole, err := c.K8sClient().RbacV1().Roles(namespace).Get(ctx, role, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if existingRole.Labels[label.ManagedBy] != project.Name() {
+		return nil
+	}
+
+	return DeleteRole(c, ctx, namespace, role)
+}
@@ -5,15 +5,20 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/rbac-operator/pkg/base"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
-// RoleBindingNeedsUpdate RoleBinding needs an update with the list of subjects has changed
+// RoleBindingNeedsUpdate RoleBinding needs an update if the list of subjects has changed, or labels
+// or annotations of the desired RoleBinding are missing or differ
 func RoleBindingNeedsUpdate(desiredRoleBinding, existingRoleBinding *rbacv1.RoleBinding) bool {
 	if len(existingRoleBinding.Subjects) != len(desiredRoleBinding.Subjects) {
 		return true
@@ -23,10 +28,45 @@ func RoleBindingNeedsUpdate(desiredRoleBinding, existingRoleBinding *rbacv1.Role
 		return true
 	}
 
+	if !isSubset(desiredRoleBinding.Labels, existingRoleBinding.Labels) {
+		return true
+	}
+
+	if !isSubset(desiredRoleBinding.Annotations, existingRoleBinding.Annotations) {
+		return true
+	}
+
 	return false
 }
 
-func CreateOrUpdateRoleBinding(c base.K8sClientWithLogging, ctx context.Context, namespace string, roleBinding *rbacv1.RoleBinding) error {
+func isSubset(subset, set map[string]string) bool {
+	for k, v := range subset {
+		if value, ok := set[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateOrUpdateRoleBinding only binds the subjects allowed in the namespace by
+// the given protection policy.
+func CreateOrUpdateRoleBinding(c base.K8sClientWithLogging, ctx context.Context, policy protection.Policy, namespace string, roleBinding *rbacv1.RoleBinding) error {
+	skip, err := pause.SkipInNamespace(c, ctx, "RoleBinding", namespace, roleBinding.Name)
+	if err != nil {
+		return microerror.Mask(err)
+	} else if skip {
+		return nil
+	}
+
+	// subjects not allowed by the protection policy of the namespace are never bound
+	if policy.IsProtected(namespace) {
+		roleBinding = roleBinding.DeepCopy()
+		roleBinding.Subjects = policy.FilterSubjects(namespace, roleBinding.Subjects)
+		if len(roleBinding.Subjects) == 0 {
+			return deleteProtectedRoleBinding(c, ctx, namespace, roleBinding.Name)
+		}
+	}
+
 	existingRoleBinding, err := c.K8sClient().RbacV1().RoleBindings(namespace).Get(ctx, roleBinding.Name, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Creating RoleBinding %#q in namespace %s.", roleBinding.Name, namespace))
@@ -42,6 +82,8 @@ func CreateOrUpdateRoleBinding(c base.K8sClientWithLogging, ctx context.Context,
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingRoleBinding) {
+		pause.Skip(c.Logger(), ctx, "RoleBinding", namespace, roleBinding.Name)
 	} else if RoleBindingNeedsUpdate(roleBinding, existingRoleBinding) {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Updating RoleBinding %#q in namespace %s.", roleBinding.Name, namespace))
 		_, err := c.K8sClient().RbacV1().RoleBindings(namespace).Update(ctx, roleBinding, metav1.UpdateOptions{})
@@ -56,13 +98,20 @@ func CreateOrUpdateRoleBinding(c base.K8sClientWithLogging, ctx context.Context,
 }
 
 func DeleteRoleBinding(c base.K8sClientWithLogging, ctx context.Context, namespace string, roleBinding string) error {
-	var err error
+	skip, err := pause.SkipInNamespace(c, ctx, "RoleBinding", namespace, roleBinding)
+	if err != nil {
+		return microerror.Mask(err)
+	} else if skip {
+		return nil
+	}
 
-	_, err = c.K8sClient().RbacV1().RoleBindings(namespace).Get(ctx, roleBinding, metav1.GetOptions{})
+	existingRoleBinding, err := c.K8sClient().RbacV1().RoleBindings(namespace).Get(ctx, roleBinding, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// nothing to be done
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingRoleBinding) {
+		pause.Skip(c.Logger(), ctx, "RoleBinding", namespace, roleBinding)
 	} else {
 		c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("Deleting RoleBinding %#q in namespace %s.", roleBinding, namespace))
 
@@ -76,3 +125,39 @@ func DeleteRoleBinding(c base.K8sClientWithLogging, ctx context.Context, namespa
 	}
 	return nil
 }
+
+// DeleteManagedRoleBinding deletes the RoleBinding only if it is managed by
+// rbac-operator, e.g. once none of its subjects are to be bound anymore.
+func DeleteManagedRoleBinding(c base.K8sClientWithLogging, ctx context.Context, namespace string, roleBinding string) error {
+	existingRoleBinding, err := c.K8sClient().RbacV1().RoleBindings(namespace).Get(ctx, roleBinding, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if existingRoleBinding.Labels[label.ManagedBy] != project.Name() {
+		return nil
+	}
+
+	return DeleteRoleBinding(c, ctx, namespace, roleBinding)
+}
+
+// deleteProtectedRoleBinding removes a RoleBinding managed by rbac-operator from a protected
+// namespace once none of its subjects are allowed there.
+func deleteProtectedRoleBinding(c base.K8sClientWithLogging, ctx context.Context, namespace string, roleBinding string) error {
+	existingRoleBinding, err := c.K8sClient().RbacV1().RoleBindings(namespace).Get(ctx, roleBinding, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if existingRoleBinding.Labels[label.ManagedBy] != project.Name() {
+		return nil
+	}
+
+	c.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("None of the subjects of RoleBinding %#q are allowed in protected namespace %s.", roleBinding, namespace))
+
+	return DeleteRoleBinding(c, ctx, namespace, roleBinding)
+}
@@ -4,16 +4,20 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/giantswarm/microerror"
 	microserver "github.com/giantswarm/microkit/server"
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/viper"
 
+	"github.com/giantswarm/rbac-operator/flag"
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/server/endpoint"
+	"github.com/giantswarm/rbac-operator/server/webhook"
 	"github.com/giantswarm/rbac-operator/service"
 )
 
@@ -21,10 +25,18 @@ type Config struct {
 	Logger  micrologger.Logger
 	Service *service.Service
 
+	Flag  *flag.Flag
 	Viper *viper.Viper
 }
 
 func New(config Config) (microserver.Server, error) {
+	if config.Flag == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Flag must not be empty")
+	}
+	if config.Viper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Viper must not be empty")
+	}
+
 	var err error
 
 	var endpointCollection *endpoint.Endpoint
@@ -40,9 +52,41 @@ func New(config Config) (microserver.Server, error) {
 		}
 	}
 
+	// The admission webhook must be served via TLS, which is why it gets its
+	// own listener next to the microkit server.
+	var webhookServer *http.Server
+	if address := config.Viper.GetString(config.Flag.Service.Webhook.Address); address != "" {
+		var roleBindingTemplateWebhook *webhook.Webhook
+		{
+			c := webhook.Config{
+				Logger: config.Logger,
+
+				Protection: config.Service.ProtectionPolicy(),
+			}
+
+			roleBindingTemplateWebhook, err = webhook.New(c)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(webhook.Path, roleBindingTemplateWebhook)
+
+		webhookServer = &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
 	s := &server{
 		logger: config.Logger,
 
+		webhookCrtFile: config.Viper.GetString(config.Flag.Service.Webhook.TLS.CrtFile),
+		webhookKeyFile: config.Viper.GetString(config.Flag.Service.Webhook.TLS.KeyFile),
+		webhookServer:  webhookServer,
+
 		bootOnce: sync.Once{},
 		config: microserver.Config{
 			Logger:      config.Logger,
@@ -64,6 +108,10 @@ func New(config Config) (microserver.Server, error) {
 type server struct {
 	logger micrologger.Logger
 
+	webhookCrtFile string
+	webhookKeyFile string
+	webhookServer  *http.Server
+
 	bootOnce     sync.Once
 	config       microserver.Config
 	shutdownOnce sync.Once
@@ -71,8 +119,18 @@ type server struct {
 
 func (s *server) Boot() {
 	s.bootOnce.Do(func() {
-		// Here goes your custom boot logic for your server/endpoint if
-		// any.
+		if s.webhookServer == nil {
+			return
+		}
+
+		go func() {
+			s.logger.Log("level", "info", "message", "starting admission webhook server", "address", s.webhookServer.Addr)
+
+			err := s.webhookServer.ListenAndServeTLS(s.webhookCrtFile, s.webhookKeyFile)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic(microerror.JSON(microerror.Mask(err)))
+			}
+		}()
 	})
 }
 
@@ -82,8 +140,17 @@ func (s *server) Config() microserver.Config {
 
 func (s *server) Shutdown() {
 	s.shutdownOnce.Do(func() {
-		// Here goes your custom shutdown logic for your
-		// server/endpoint if any.
+		if s.webhookServer == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := s.webhookServer.Shutdown(ctx)
+		if err != nil {
+			s.logger.Errorf(ctx, err, "failed to shut down admission webhook server")
+		}
 	})
 }
 
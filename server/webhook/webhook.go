@@ -0,0 +1,139 @@
+// Package webhook implements the validating admission webhook for
+// RoleBindingTemplates.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/resource/rolebinding"
+)
+
+const (
+	// Path is the path the RoleBindingTemplate webhook is served at.
+	Path = "/validate-rolebindingtemplate"
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	// Protection is used to warn about subjects which are not bound in
+	// protected namespaces, it defaults to protection.DefaultPolicy().
+	Protection protection.Policy
+}
+
+type Webhook struct {
+	logger micrologger.Logger
+
+	protection protection.Policy
+}
+
+func New(config Config) (*Webhook, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	w := &Webhook{
+		logger: config.Logger,
+
+		protection: config.Protection.OrDefault(),
+	}
+
+	return w, nil
+}
+
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	err := json.NewDecoder(r.Body).Decode(&review)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(rw, "admission review does not contain a request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = w.review(review.Request)
+	review.Request = nil
+
+	rw.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(rw).Encode(review)
+	if err != nil {
+		w.logger.Errorf(r.Context(), err, "failed to encode admission review")
+	}
+}
+
+func (w *Webhook) review(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{
+		UID:     request.UID,
+		Allowed: true,
+	}
+
+	if request.Operation == admissionv1.Delete {
+		return response
+	}
+
+	var template v1alpha1.RoleBindingTemplate
+	err := json.Unmarshal(request.Object.Raw, &template)
+	if err != nil {
+		return deny(response, http.StatusBadRequest, fmt.Sprintf("failed to decode RoleBindingTemplate: %v", err))
+	}
+
+	// Templates being deleted must be allowed through, e.g. for their
+	// finalizers to be removed, even if they no longer pass validation.
+	if template.DeletionTimestamp != nil {
+		return response
+	}
+
+	// Updates which only change metadata or status are not validated again,
+	// so that templates created before a validation rule was added can still
+	// be labelled, annotated or have their status written.
+	if request.Operation == admissionv1.Update && len(request.OldObject.Raw) > 0 {
+		var oldTemplate v1alpha1.RoleBindingTemplate
+		err = json.Unmarshal(request.OldObject.Raw, &oldTemplate)
+		if err != nil {
+			return deny(response, http.StatusBadRequest, fmt.Sprintf("failed to decode old RoleBindingTemplate: %v", err))
+		}
+
+		if reflect.DeepEqual(oldTemplate.Spec, template.Spec) {
+			return response
+		}
+	}
+
+	warnings, err := rolebinding.ValidateTemplate(template, w.protection)
+	if rolebinding.IsInvalidConfig(err) {
+		return deny(response, http.StatusUnprocessableEntity, err.Error())
+	} else if err != nil {
+		return deny(response, http.StatusInternalServerError, err.Error())
+	}
+
+	response.Warnings = warnings
+
+	return response
+}
+
+func deny(response *admissionv1.AdmissionResponse, code int32, message string) *admissionv1.AdmissionResponse {
+	response.Allowed = false
+	response.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Message: message,
+	}
+
+	return response
+}
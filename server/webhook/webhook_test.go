@@ -0,0 +1,210 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+)
+
+func TestServeHTTP(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Operation   admissionv1.Operation
+		Template    v1alpha1.RoleBindingTemplateResource
+		OldTemplate *v1alpha1.RoleBindingTemplateResource
+		Deleting    bool
+
+		expectedAllowed  bool
+		expectedWarnings []string
+		expectedMessage  string
+	}{
+		{
+			Name:      "case0: allow valid template",
+			Operation: admissionv1.Create,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "automation"},
+				},
+			},
+			expectedAllowed: true,
+		},
+		{
+			Name:      "case1: allow template with warnings",
+			Operation: admissionv1.Update,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedAllowed: true,
+			expectedWarnings: []string{
+				"subject Group `test-group` is not bound in protected namespace org-giantswarm",
+			},
+		},
+		{
+			Name:      "case2: deny template without roleRef",
+			Operation: admissionv1.Create,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.template.roleRef must reference a Role or ClusterRole by name",
+		},
+		{
+			Name:      "case3: allow removing the finalizer of an invalid template being deleted",
+			Operation: admissionv1.Update,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			OldTemplate: &v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			Deleting:        true,
+			expectedAllowed: true,
+		},
+		{
+			Name:      "case4: allow metadata updates of an invalid template",
+			Operation: admissionv1.Update,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			OldTemplate: &v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedAllowed: true,
+		},
+		{
+			Name:      "case5: deny spec updates making a template invalid",
+			Operation: admissionv1.Update,
+			Template: v1alpha1.RoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			OldTemplate: &v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedAllowed: false,
+			expectedMessage: "spec.template.roleRef must reference a Role or ClusterRole by name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			w, err := New(Config{Logger: microloggertest.New()})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			template := v1alpha1.RoleBindingTemplate{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.GroupVersion.String(),
+					Kind:       "RoleBindingTemplate",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "something",
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: tc.Template,
+				},
+			}
+
+			var oldRaw []byte
+			if tc.OldTemplate != nil {
+				oldTemplate := template.DeepCopy()
+				oldTemplate.Spec.Template = *tc.OldTemplate
+				oldTemplate.Finalizers = []string{"rbac-operator.giantswarm.io/rolebindingtemplate"}
+				if tc.Deleting {
+					deletionTimestamp := metav1.Now()
+					oldTemplate.DeletionTimestamp = &deletionTimestamp
+				}
+				oldRaw, err = json.Marshal(oldTemplate)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.Deleting {
+				deletionTimestamp := metav1.Now()
+				template.DeletionTimestamp = &deletionTimestamp
+			}
+
+			raw, err := json.Marshal(template)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			review := admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: admissionv1.SchemeGroupVersion.String(),
+					Kind:       "AdmissionReview",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					UID:       types.UID("test-uid"),
+					Operation: tc.Operation,
+					Object:    runtime.RawExtension{Raw: raw},
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+				},
+			}
+			body, err := json.Marshal(review)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := httptest.NewRecorder()
+			w.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+			}
+
+			var result admissionv1.AdmissionReview
+			err = json.NewDecoder(recorder.Body).Decode(&result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Response == nil {
+				t.Fatal("Expected admission response, got nil")
+			}
+			if result.Response.UID != review.Request.UID {
+				t.Fatalf("Expected UID %q, got %q", review.Request.UID, result.Response.UID)
+			}
+			if result.Response.Allowed != tc.expectedAllowed {
+				t.Fatalf("Expected allowed %t, got %t", tc.expectedAllowed, result.Response.Allowed)
+			}
+			if !reflect.DeepEqual(tc.expectedWarnings, result.Response.Warnings) {
+				t.Fatalf("Expected warnings %v, got %v", tc.expectedWarnings, result.Response.Warnings)
+			}
+			if tc.expectedMessage != "" && (result.Response.Result == nil || !strings.Contains(result.Response.Result.Message, tc.expectedMessage)) {
+				t.Fatalf("Expected message to contain %q, got %v", tc.expectedMessage, result.Response.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/giantswarm/microerror"
+	daemonflag "github.com/giantswarm/microkit/command/daemon/flag"
+	"github.com/giantswarm/micrologger"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+
+	"github.com/giantswarm/rbac-operator/flag"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+// newAccessGroups reads the access groups from the configuration, including
+// the legacy single group settings. Malformed or invalid access groups are
+// rejected instead of being dropped, as that would revoke access.
+func newAccessGroups(v *viper.Viper, f *flag.Flag) (accessgroup.AccessGroups, error) {
+	var accessGroups accessgroup.AccessGroups
+
+	// the flag default is an empty string, which means no access groups are configured
+	if value, ok := v.Get(f.Service.AccessGroups).(string); !ok || strings.TrimSpace(value) != "" {
+		err := v.UnmarshalKey(f.Service.AccessGroups, &accessGroups, func(c *mapstructure.DecoderConfig) {
+			c.ErrorUnused = true
+		})
+		if err != nil {
+			return accessgroup.AccessGroups{}, microerror.Maskf(invalidConfigError, "access groups could not be parsed: %v", err)
+		}
+	}
+
+	legacyCustomerAdminGroup := v.GetString(f.Service.WriteAllCustomerGroup)
+	accessGroups.AddLegacyCustomerAdminGroup(legacyCustomerAdminGroup)
+
+	legacyGiantswarmAdminGroup := v.GetString(f.Service.WriteAllGiantswarmGroup)
+	accessGroups.AddLegacyGiantswarmAdminGroup(legacyGiantswarmAdminGroup)
+
+	if !accessGroups.HasValidWriteAllGiantswarmAdminGroups() {
+		return accessgroup.AccessGroups{}, microerror.Maskf(invalidConfigError, "Giantswarm Write All Admin groups must not be empty")
+	}
+
+	if message := accessGroups.Validate(); message != "" {
+		return accessgroup.AccessGroups{}, microerror.Maskf(invalidConfigError, "access groups are invalid: %s", message)
+	}
+
+	return accessGroups, nil
+}
+
+// logAccessGroupWarnings logs the warnings about the configured access groups.
+// Their number is exposed by the access groups collector.
+func logAccessGroupWarnings(ctx context.Context, logger micrologger.Logger, accessGroups *accessgroup.Store) {
+	for _, warning := range accessGroups.Warnings() {
+		logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("access group configuration: %s", warning))
+	}
+}
+
+// watchAccessGroups reloads the access groups whenever one of the config
+// files changes, e.g. when the kubelet updates the mounted ConfigMap.
+func (s *Service) watchAccessGroups(ctx context.Context) {
+	configFlag := daemonflag.New().Config
+
+	for _, file := range s.viper.GetStringSlice(configFlag.Files) {
+		v := s.newConfigFileViper(file)
+
+		err := v.ReadInConfig()
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			continue
+		} else if err != nil {
+			s.logger.Errorf(ctx, err, "Could not watch config file %s for access group changes", file)
+			continue
+		}
+
+		v.OnConfigChange(func(e fsnotify.Event) {
+			s.reloadAccessGroups(ctx)
+		})
+		v.WatchConfig()
+	}
+}
+
+// reloadAccessGroups reads the access groups from the config files again and
+// requeues the objects of all controllers depending on them if they changed.
+// Invalid access groups are logged and the current ones are kept.
+func (s *Service) reloadAccessGroups(ctx context.Context) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	v, err := s.readConfigFiles()
+	if err != nil {
+		s.logger.Errorf(ctx, err, "Could not read config files to reload access groups")
+		return
+	}
+
+	accessGroups, err := newAccessGroups(v, s.flag)
+	if err != nil {
+		s.logger.Errorf(ctx, err, "Keeping current access groups, the changed ones are invalid")
+		s.accessGroups.SetInvalid()
+		return
+	}
+
+	changed := s.accessGroups.Set(accessGroups)
+	logAccessGroupWarnings(ctx, s.logger, s.accessGroups)
+	if !changed {
+		return
+	}
+	s.logger.Debugf(ctx, "Access groups changed, requeueing all controllers")
+
+	err = s.resyncAccessGroupConsumers(ctx)
+	if err != nil {
+		s.logger.Errorf(ctx, err, "Could not requeue all controllers after access groups changed")
+	}
+
+	// the status of AccessGroups depends on the configured access tiers
+	err = s.accessGroupController.Resync(ctx)
+	if err != nil {
+		s.logger.Errorf(ctx, err, "Could not requeue AccessGroups after access groups changed")
+	}
+}
+
+// resyncAccessGroupConsumers requeues the objects of all controllers binding
+// access groups. All controllers are requeued even if some fail, the first
+// error is returned.
+func (s *Service) resyncAccessGroupConsumers(ctx context.Context) error {
+	var first error
+	for _, resync := range []func(context.Context) error{
+		s.clusterController.Resync,
+		s.rbacController.Resync,
+		s.crossplaneController.Resync,
+		s.roleBindingTemplateController.Resync,
+	} {
+		err := resync(ctx)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return microerror.Mask(first)
+	}
+
+	return nil
+}
+
+// readConfigFiles merges the access group settings from the config files into
+// a new viper, the same way microkit does at start-up. Settings missing from
+// the files keep the value they had at start-up.
+func (s *Service) readConfigFiles() (*viper.Viper, error) {
+	configFlag := daemonflag.New().Config
+	keys := []string{
+		s.flag.Service.AccessGroups,
+		s.flag.Service.WriteAllCustomerGroup,
+		s.flag.Service.WriteAllGiantswarmGroup,
+	}
+
+	merged := viper.New()
+	for _, key := range keys {
+		merged.Set(key, s.viper.Get(key))
+	}
+
+	for _, file := range s.viper.GetStringSlice(configFlag.Files) {
+		v := s.newConfigFileViper(file)
+
+		err := v.ReadInConfig()
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			continue
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, key := range keys {
+			if v.IsSet(key) {
+				merged.Set(key, v.Get(key))
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+func (s *Service) newConfigFileViper(file string) *viper.Viper {
+	configFlag := daemonflag.New().Config
+
+	v := viper.New()
+	for _, dir := range s.viper.GetStringSlice(configFlag.Dirs) {
+		v.AddConfigPath(dir)
+	}
+	v.SetConfigName(file)
+
+	return v
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/giantswarm/rbac-operator/flag"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+func Test_readConfigFiles(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Config string
+
+		ExpectedGroups accessgroup.AccessGroups
+		ExpectedError  bool
+	}{
+		{
+			Name: "case 0: access groups are read from the changed config file",
+			Config: `
+service:
+  accessGroups:
+    writeAllCustomerGroups:
+    - name: customer:acme:Admins
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+`,
+			ExpectedGroups: accessgroup.AccessGroups{
+				WriteAllCustomerGroups:   []accessgroup.AccessGroup{{Name: "customer:acme:Admins"}},
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:giantswarm:giantswarm-admins"}},
+			},
+		},
+		{
+			Name: "case 1: access groups missing from the config file keep their start-up value",
+			Config: `
+service:
+  provider: aws
+`,
+			ExpectedGroups: accessgroup.AccessGroups{
+				WriteAllCustomerGroups:   []accessgroup.AccessGroup{{Name: "customer:acme:Employees"}},
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:giantswarm:giantswarm-admins"}},
+			},
+		},
+		{
+			Name: "case 2: changed config without Giant Swarm admin groups is invalid",
+			Config: `
+service:
+  accessGroups:
+    writeAllCustomerGroups:
+    - name: customer:acme:Admins
+`,
+			ExpectedError: true,
+		},
+		{
+			Name: "case 3: access tiers are read from the config file",
+			Config: `
+service:
+  accessGroups:
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+    tiers:
+    - name: platform
+      groups:
+      - name: customer:acme:Platform
+      clusterRoles:
+      - write-silences
+      organizationNamespaceClusterRoles:
+      - write-flux-resources
+`,
+			ExpectedGroups: accessgroup.AccessGroups{
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:giantswarm:giantswarm-admins"}},
+				Tiers: []accessgroup.AccessTier{
+					{
+						Name:                              "platform",
+						Groups:                            []accessgroup.AccessGroup{{Name: "customer:acme:Platform"}},
+						ClusterRoles:                      []string{"write-silences"},
+						OrganizationNamespaceClusterRoles: []string{"write-flux-resources"},
+					},
+				},
+			},
+		},
+		{
+			Name: "case 4: access tiers with an invalid name are rejected",
+			Config: `
+service:
+  accessGroups:
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+    tiers:
+    - name: customer-admins
+      groups:
+      - name: customer:acme:Platform
+      clusterRoles:
+      - write-silences
+`,
+			ExpectedError: true,
+		},
+		{
+			Name: "case 5: users and service accounts are read from the config file",
+			Config: `
+service:
+  accessGroups:
+    writeAllCustomerGroups:
+    - name: jane@acme.com
+      kind: User
+    - name: deployer
+      kind: ServiceAccount
+      namespace: org-acme
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+`,
+			ExpectedGroups: accessgroup.AccessGroups{
+				WriteAllCustomerGroups: []accessgroup.AccessGroup{
+					{Name: "jane@acme.com", Kind: "User"},
+					{Name: "deployer", Kind: "ServiceAccount", Namespace: "org-acme"},
+				},
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:giantswarm:giantswarm-admins"}},
+			},
+		},
+		{
+			Name: "case 6: service accounts without namespace are rejected",
+			Config: `
+service:
+  accessGroups:
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+    - name: deployer
+      kind: ServiceAccount
+`,
+			ExpectedError: true,
+		},
+		{
+			Name: "case 7: malformed access groups are rejected",
+			Config: `
+service:
+  accessGroups:
+    writeAllCustomerGroups:
+    - nmae: customer:acme:Admins
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+`,
+			ExpectedError: true,
+		},
+		{
+			Name: "case 8: access groups which are no list are rejected",
+			Config: `
+service:
+  accessGroups:
+    writeAllCustomerGroups: customer:acme:Admins
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+`,
+			ExpectedError: true,
+		},
+		{
+			Name: "case 9: access groups without name are rejected",
+			Config: `
+service:
+  accessGroups:
+    readAllCustomerGroups:
+    - name: ""
+    writeAllGiantswarmGroups:
+    - name: giantswarm:giantswarm:giantswarm-admins
+`,
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte(tc.Config), 0600)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %s", err)
+			}
+
+			v := viper.New()
+			v.Set("config.dirs", []string{dir})
+			v.Set("config.files", []string{"config"})
+			v.Set("service.accessGroups.writeAllCustomerGroups", []map[string]string{{"name": "customer:acme:Employees"}})
+			v.Set("service.accessGroups.writeAllGiantswarmGroups", []map[string]string{{"name": "giantswarm:giantswarm:giantswarm-admins"}})
+
+			s := &Service{
+				flag:  flag.New(),
+				viper: v,
+			}
+
+			merged, err := s.readConfigFiles()
+			if err != nil {
+				t.Fatalf("Failed to read config files: %s", err)
+			}
+
+			groups, err := newAccessGroups(merged, s.flag)
+			if tc.ExpectedError {
+				if !IsInvalidConfig(err) {
+					t.Fatalf("Expected invalid config error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if !reflect.DeepEqual(tc.ExpectedGroups, groups) {
+				t.Fatalf("Expected access groups %v, got %v", tc.ExpectedGroups, groups)
+			}
+		})
+	}
+}
+
+func Test_newAccessGroups_flagDefault(t *testing.T) {
+	f := flag.New()
+
+	v := viper.New()
+	v.Set(f.Service.AccessGroups, "")
+	v.Set(f.Service.WriteAllGiantswarmGroup, "giantswarm:giantswarm:giantswarm-admins")
+
+	groups, err := newAccessGroups(v, f)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	expected := accessgroup.AccessGroups{
+		WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:giantswarm:giantswarm-admins"}},
+	}
+	if !reflect.DeepEqual(expected, groups) {
+		t.Fatalf("Expected access groups %v, got %v", expected, groups)
+	}
+}
@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+var (
+	AccessGroupConfigValidDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName("rbac_operator", "access_groups", "config_valid"),
+		"Whether the access group configuration read last is valid. If not, the previous access groups are still used.",
+		nil,
+		nil,
+	)
+	AccessGroupConfigWarningsDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName("rbac_operator", "access_groups", "config_warnings"),
+		"Number of warnings about the access group configuration in use.",
+		nil,
+		nil,
+	)
+)
+
+type AccessGroupConfigConfig struct {
+	AccessGroups *accessgroup.Store
+}
+
+// AccessGroupConfig exposes whether the access group configuration is valid
+// and the number of warnings about it.
+type AccessGroupConfig struct {
+	accessGroups *accessgroup.Store
+}
+
+func NewAccessGroupConfig(config AccessGroupConfigConfig) (*AccessGroupConfig, error) {
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
+
+	a := &AccessGroupConfig{
+		accessGroups: config.AccessGroups,
+	}
+
+	return a, nil
+}
+
+func (a *AccessGroupConfig) Collect(ch chan<- prometheus.Metric) error {
+	valid := 0.0
+	if a.accessGroups.Valid() {
+		valid = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		AccessGroupConfigValidDesc,
+		prometheus.GaugeValue,
+		valid,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		AccessGroupConfigWarningsDesc,
+		prometheus.GaugeValue,
+		float64(len(a.accessGroups.Warnings())),
+	)
+
+	return nil
+}
+
+func (a *AccessGroupConfig) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- AccessGroupConfigValidDesc
+	ch <- AccessGroupConfigWarningsDesc
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+)
+
+const (
+	labelKind = "kind"
+)
+
+var (
+	PausedSkippedDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName("rbac_operator", "paused", "skipped_total"),
+		"Number of times a change to a paused object was skipped.",
+		[]string{
+			labelKind,
+		},
+		nil,
+	)
+)
+
+type PausedSkippedConfig struct {
+}
+
+// PausedSkipped exposes the number of changes to objects carrying the paused
+// annotation, or in paused namespaces, which were skipped.
+type PausedSkipped struct {
+}
+
+func NewPausedSkipped(config PausedSkippedConfig) (*PausedSkipped, error) {
+	p := &PausedSkipped{}
+
+	return p, nil
+}
+
+func (p *PausedSkipped) Collect(ch chan<- prometheus.Metric) error {
+	for kind, count := range pause.SkippedTotal() {
+		ch <- prometheus.MustNewConstMetric(
+			PausedSkippedDesc,
+			prometheus.CounterValue,
+			count,
+			kind,
+		)
+	}
+
+	return nil
+}
+
+func (p *PausedSkipped) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- PausedSkippedDesc
+
+	return nil
+}
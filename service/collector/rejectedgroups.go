@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+const (
+	labelTier   = "tier"
+	labelGroup  = "group"
+	labelPrefix = "prefix"
+)
+
+var (
+	RejectedGroupDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName("rbac_operator", "access_groups", "rejected"),
+		"Groups which are not bound since they lack the group prefix of their tier.",
+		[]string{
+			labelTier,
+			labelGroup,
+			labelPrefix,
+		},
+		nil,
+	)
+)
+
+type RejectedGroupsConfig struct {
+	AccessGroups *accessgroup.Store
+}
+
+// RejectedGroups exposes the access groups rejected for lacking the group
+// prefix of their tier.
+type RejectedGroups struct {
+	accessGroups *accessgroup.Store
+}
+
+func NewRejectedGroups(config RejectedGroupsConfig) (*RejectedGroups, error) {
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
+
+	r := &RejectedGroups{
+		accessGroups: config.AccessGroups,
+	}
+
+	return r, nil
+}
+
+func (r *RejectedGroups) Collect(ch chan<- prometheus.Metric) error {
+	for _, group := range r.accessGroups.Rejected() {
+		ch <- prometheus.MustNewConstMetric(
+			RejectedGroupDesc,
+			prometheus.GaugeValue,
+			1,
+			group.Tier,
+			group.Name,
+			group.Prefix,
+		)
+	}
+
+	return nil
+}
+
+func (r *RejectedGroups) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- RejectedGroupDesc
+
+	return nil
+}
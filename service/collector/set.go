@@ -5,11 +5,15 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
 
 type SetConfig struct {
 	K8sClient kubernetes.Interface
 	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
 }
 
 // Set is basically only a wrapper for the operator's collector implementations.
@@ -28,11 +32,33 @@ func NewSet(config SetConfig) (*Set, error) {
 		return nil, microerror.Mask(err)
 	}
 
+	rejectedGroups, err := NewRejectedGroups(RejectedGroupsConfig{
+		AccessGroups: config.AccessGroups,
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	accessGroupConfig, err := NewAccessGroupConfig(AccessGroupConfigConfig{
+		AccessGroups: config.AccessGroups,
+	})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	pausedSkipped, err := NewPausedSkipped(PausedSkippedConfig{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	var collectorSet *collector.Set
 	{
 		c := collector.SetConfig{
 			Collectors: []collector.Interface{
 				todo,
+				rejectedGroups,
+				accessGroupConfig,
+				pausedSkipped,
 			},
 			Logger: config.Logger,
 		}
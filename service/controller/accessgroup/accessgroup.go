@@ -0,0 +1,113 @@
+package accessgroup
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/controller"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/resource/declaration"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+)
+
+type AccessGroupConfig struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
+	// OnChange is called after the declared access groups changed.
+	OnChange func(ctx context.Context) error
+}
+
+type AccessGroup struct {
+	*controller.Controller
+
+	accessGroups *accessgroup.Store
+	k8sClient    k8sclient.Interface
+}
+
+func NewAccessGroup(config AccessGroupConfig) (*AccessGroup, error) {
+	var err error
+
+	var resources []resource.Interface
+	{
+		c := accessGroupResourcesConfig(config)
+
+		resources, err = newAccessGroupResources(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var accessGroupController *controller.Controller
+	{
+		c := controller.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(v1alpha1.AccessGroup)
+			},
+			Resources: resources,
+
+			// Name is used to compute finalizer names. This here results in something
+			// like operatorkit.giantswarm.io/rbac-operator-accessgroup-controller.
+			Name: project.Name() + "-accessgroup-controller",
+		}
+
+		accessGroupController, err = controller.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	c := &AccessGroup{
+		Controller: accessGroupController,
+
+		accessGroups: config.AccessGroups,
+		k8sClient:    config.K8sClient,
+	}
+
+	return c, nil
+}
+
+// Load adds the access tiers granted by all AccessGroups to the access groups,
+// so that the other controllers bind them from their first reconciliation on.
+func (c *AccessGroup) Load(ctx context.Context) error {
+	declarations, err := declaration.ListDeclarations(ctx, c.k8sClient.CtrlClient(), "")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	c.accessGroups.SetDeclarations(declarations)
+
+	return nil
+}
+
+// Resync requeues all AccessGroups, e.g. after the configured access tiers
+// changed.
+func (c *AccessGroup) Resync(ctx context.Context) error {
+	accessGroups := &v1alpha1.AccessGroupList{}
+	err := c.k8sClient.CtrlClient().List(ctx, accessGroups)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var objects []client.Object
+	for i := range accessGroups.Items {
+		objects = append(objects, &accessGroups.Items[i])
+	}
+
+	err = resync.Enqueue(ctx, c.k8sClient.CtrlClient(), objects...)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
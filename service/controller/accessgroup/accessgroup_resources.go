@@ -0,0 +1,69 @@
+package accessgroup
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
+
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/resource/declaration"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+type accessGroupResourcesConfig struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
+	OnChange     func(ctx context.Context) error
+}
+
+func newAccessGroupResources(config accessGroupResourcesConfig) ([]resource.Interface, error) {
+	var err error
+
+	var declarationResource resource.Interface
+	{
+		c := declaration.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			AccessGroups: config.AccessGroups,
+			OnChange:     config.OnChange,
+		}
+
+		declarationResource, err = declaration.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	resources := []resource.Interface{
+		declarationResource,
+	}
+
+	{
+		c := retryresource.WrapConfig{
+			Logger: config.Logger,
+		}
+
+		resources, err = retryresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	{
+		c := metricsresource.WrapConfig{}
+
+		resources, err = metricsresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,40 @@
+package key
+
+import (
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+func ToAccessGroup(v interface{}) (v1alpha1.AccessGroup, error) {
+	if v == nil {
+		return v1alpha1.AccessGroup{}, microerror.Maskf(wrongTypeError, "expected non-nil, got %#v'", v)
+	}
+
+	p, ok := v.(*v1alpha1.AccessGroup)
+	if !ok {
+		return v1alpha1.AccessGroup{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", p, v)
+	}
+
+	c := p.DeepCopy()
+
+	return *c, nil
+}
+
+// ToDeclaration returns the access tier granted by an AccessGroup.
+func ToDeclaration(accessGroup v1alpha1.AccessGroup) accessgroup.Declaration {
+	declaration := accessgroup.Declaration{
+		Tier:          accessGroup.Spec.Tier,
+		Organizations: accessGroup.Spec.Organizations,
+	}
+	for _, subject := range accessGroup.Spec.Subjects {
+		declaration.Groups = append(declaration.Groups, accessgroup.AccessGroup{
+			Name:      subject.Name,
+			Kind:      subject.Kind,
+			Namespace: subject.Namespace,
+		})
+	}
+
+	return declaration
+}
@@ -0,0 +1,123 @@
+package declaration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/key"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	accessGroup, err := key.ToAccessGroup(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.updateDeclarations(ctx, "")
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	configured := r.accessGroups.Configured()
+	if message := configured.ValidateDeclaration(key.ToDeclaration(accessGroup)); message != "" {
+		r.logger.Debugf(ctx, "AccessGroup %s is invalid: %s", accessGroup.Name, message)
+		accessGroup.Status.Bindings = nil
+		setCondition(&accessGroup, metav1.ConditionFalse, "Invalid", message)
+	} else {
+		bindings, err := r.findBindings(ctx, accessgroup.GroupsToSubjects(key.ToDeclaration(accessGroup).Groups))
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		accessGroup.Status.Bindings = bindings
+		setCondition(&accessGroup, metav1.ConditionTrue, "Applied", fmt.Sprintf("Subjects are bound in %d bindings", len(bindings)))
+	}
+
+	accessGroup.Status.ObservedGeneration = accessGroup.Generation
+	if err := r.k8sClient.CtrlClient().Status().Update(ctx, &accessGroup); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// findBindings returns the ClusterRoleBindings and RoleBindings managed by rbac-operator
+// which bind one of the subjects.
+func (r *Resource) findBindings(ctx context.Context, subjects []rbacv1.Subject) ([]v1alpha1.AccessGroupBinding, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", label.ManagedBy, project.Name()),
+	}
+
+	var bindings []v1alpha1.AccessGroupBinding
+
+	clusterRoleBindings, err := r.k8sClient.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, listOptions)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	for _, clusterRoleBinding := range clusterRoleBindings.Items {
+		if bindsAnySubject(clusterRoleBinding.Subjects, subjects) {
+			bindings = append(bindings, v1alpha1.AccessGroupBinding{
+				Kind:        "ClusterRoleBinding",
+				Name:        clusterRoleBinding.Name,
+				ClusterRole: clusterRoleBinding.RoleRef.Name,
+			})
+		}
+	}
+
+	roleBindings, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	for _, roleBinding := range roleBindings.Items {
+		if bindsAnySubject(roleBinding.Subjects, subjects) {
+			bindings = append(bindings, v1alpha1.AccessGroupBinding{
+				Kind:        "RoleBinding",
+				Namespace:   roleBinding.Namespace,
+				Name:        roleBinding.Name,
+				ClusterRole: roleBinding.RoleRef.Name,
+			})
+		}
+	}
+
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].Kind != bindings[j].Kind {
+			return bindings[i].Kind < bindings[j].Kind
+		}
+		if bindings[i].Namespace != bindings[j].Namespace {
+			return bindings[i].Namespace < bindings[j].Namespace
+		}
+		return bindings[i].Name < bindings[j].Name
+	})
+
+	return bindings, nil
+}
+
+func bindsAnySubject(bound []rbacv1.Subject, subjects []rbacv1.Subject) bool {
+	for _, b := range bound {
+		for _, s := range subjects {
+			if b.Kind == s.Kind && b.Name == s.Name && b.Namespace == s.Namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func setCondition(accessGroup *v1alpha1.AccessGroup, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&accessGroup.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: accessGroup.Generation,
+	})
+}
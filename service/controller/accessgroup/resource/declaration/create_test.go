@@ -0,0 +1,372 @@
+package declaration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/resource/usergroups"
+	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/namespaceauth"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/test"
+)
+
+func Test_EnsureCreated(t *testing.T) {
+	testCases := []struct {
+		name         string
+		accessGroups []*v1alpha1.AccessGroup
+		bindings     []runtime.Object
+
+		expectedGroups   accessgroup.AccessGroups
+		expectedChanged  bool
+		expectedReady    metav1.ConditionStatus
+		expectedBindings []v1alpha1.AccessGroupBinding
+	}{
+		{
+			name: "case 0: subjects are added to a built-in tier and their bindings reported",
+			accessGroups: []*v1alpha1.AccessGroup{
+				newAccessGroup("admins", v1alpha1.AccessGroupSpec{
+					Tier:     accessgroup.CustomerAdminsTierName,
+					Subjects: []v1alpha1.AccessGroupSubject{{Name: "customer:acme:Admins"}},
+				}),
+			},
+			bindings: []runtime.Object{
+				newClusterRoleBinding("write-all-customer-group", "cluster-admin", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "customer:acme:Admins"}),
+				newClusterRoleBinding("write-all-giantswarm-group", "cluster-admin", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "giantswarm:admins"}),
+			},
+			expectedGroups: accessgroup.AccessGroups{
+				WriteAllCustomerGroups:   []accessgroup.AccessGroup{{Name: "customer:acme:Admins"}},
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+				Tiers:                    []accessgroup.AccessTier{{Name: "platform", ClusterNamespaceClusterRoles: []string{"view"}}},
+			},
+			expectedChanged: true,
+			expectedReady:   metav1.ConditionTrue,
+			expectedBindings: []v1alpha1.AccessGroupBinding{
+				{Kind: "ClusterRoleBinding", Name: "write-all-customer-group", ClusterRole: "cluster-admin"},
+			},
+		},
+		{
+			name: "case 1: subjects are added to a configured tier in some organizations",
+			accessGroups: []*v1alpha1.AccessGroup{
+				newAccessGroup("platform", v1alpha1.AccessGroupSpec{
+					Tier:          "platform",
+					Subjects:      []v1alpha1.AccessGroupSubject{{Name: "deployer", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"}},
+					Organizations: []string{"acme"},
+				}),
+			},
+			expectedGroups: accessgroup.AccessGroups{
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+				Tiers:                    []accessgroup.AccessTier{{Name: "platform", ClusterNamespaceClusterRoles: []string{"view"}}},
+				Organizations: map[string]accessgroup.OrganizationAccessGroups{
+					"acme": {
+						TierGroups: map[string][]accessgroup.AccessGroup{
+							"platform": {{Name: "deployer", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"}},
+						},
+					},
+				},
+			},
+			expectedChanged: true,
+			expectedReady:   metav1.ConditionTrue,
+		},
+		{
+			name: "case 2: AccessGroups of unknown tiers are reported and ignored",
+			accessGroups: []*v1alpha1.AccessGroup{
+				newAccessGroup("unknown", v1alpha1.AccessGroupSpec{
+					Tier:     "unknown",
+					Subjects: []v1alpha1.AccessGroupSubject{{Name: "customer:acme:Admins"}},
+				}),
+			},
+			expectedGroups: accessgroup.AccessGroups{
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+				Tiers:                    []accessgroup.AccessTier{{Name: "platform", ClusterNamespaceClusterRoles: []string{"view"}}},
+			},
+			expectedReady: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1alpha1.AddToScheme(scheme.Scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ctrlObjects []runtime.Object
+			for _, accessGroup := range tc.accessGroups {
+				ctrlObjects = append(ctrlObjects, accessGroup)
+			}
+
+			k8sClientFake := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+				CtrlClient: clientfake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithRuntimeObjects(ctrlObjects...).
+					WithStatusSubresource(&v1alpha1.AccessGroup{}).
+					Build(),
+				K8sClient: clientgofake.NewSimpleClientset(tc.bindings...),
+			})
+
+			store := accessgroup.NewStore(accessgroup.AccessGroups{
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+				Tiers:                    []accessgroup.AccessTier{{Name: "platform", ClusterNamespaceClusterRoles: []string{"view"}}},
+			})
+
+			var changed bool
+			r, err := New(Config{
+				K8sClient:    k8sClientFake,
+				Logger:       microloggertest.New(),
+				AccessGroups: store,
+				OnChange: func(ctx context.Context) error {
+					changed = true
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			for _, accessGroup := range tc.accessGroups {
+				err = r.EnsureCreated(ctx, accessGroup)
+				if err != nil {
+					t.Fatalf("Expected success, got error %v", err)
+				}
+			}
+
+			if changed != tc.expectedChanged {
+				t.Fatalf("Expected change %t, got %t", tc.expectedChanged, changed)
+			}
+			if !reflect.DeepEqual(tc.expectedGroups, store.Get()) {
+				t.Fatalf("Expected access groups %v, got %v", tc.expectedGroups, store.Get())
+			}
+
+			result := &v1alpha1.AccessGroup{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: tc.accessGroups[0].Name}, result)
+			if err != nil {
+				t.Fatalf("failed to get access group: %s", err)
+			}
+			ready := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeReady)
+			if ready == nil || ready.Status != tc.expectedReady {
+				t.Fatalf("Expected Ready condition %s, got %v", tc.expectedReady, ready)
+			}
+			if !reflect.DeepEqual(tc.expectedBindings, result.Status.Bindings) {
+				t.Fatalf("Expected bindings %v, got %v", tc.expectedBindings, result.Status.Bindings)
+			}
+		})
+	}
+}
+
+func Test_EnsureDeleted(t *testing.T) {
+	err := v1alpha1.AddToScheme(scheme.Scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessGroup := newAccessGroup("admins", v1alpha1.AccessGroupSpec{
+		Tier:     accessgroup.CustomerReadersTierName,
+		Subjects: []v1alpha1.AccessGroupSubject{{Name: "customer:acme:Readers"}},
+	})
+
+	k8sClientFake := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+		CtrlClient: clientfake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithRuntimeObjects(accessGroup).
+			WithStatusSubresource(&v1alpha1.AccessGroup{}).
+			Build(),
+		K8sClient: clientgofake.NewSimpleClientset(),
+	})
+
+	configured := accessgroup.AccessGroups{
+		WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+	}
+	store := accessgroup.NewStore(configured)
+
+	r, err := New(Config{
+		K8sClient:    k8sClientFake,
+		Logger:       microloggertest.New(),
+		AccessGroups: store,
+		OnChange: func(ctx context.Context) error {
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = r.EnsureCreated(ctx, accessGroup)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	if len(store.Get().ReadAllCustomerGroups) != 1 {
+		t.Fatalf("Expected declared reader group, got %v", store.Get().ReadAllCustomerGroups)
+	}
+
+	err = r.EnsureDeleted(ctx, accessGroup)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	if !reflect.DeepEqual(configured, store.Get()) {
+		t.Fatalf("Expected configured access groups %v, got %v", configured, store.Get())
+	}
+}
+
+func Test_EnsureDeleted_RevokesAccess(t *testing.T) {
+	schemeBuilder := runtime.SchemeBuilder{
+		v1alpha1.AddToScheme,
+		security.AddToScheme,
+	}
+	err := schemeBuilder.AddToScheme(scheme.Scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessGroup := newAccessGroup("admins", v1alpha1.AccessGroupSpec{
+		Tier:     accessgroup.CustomerAdminsTierName,
+		Subjects: []v1alpha1.AccessGroupSubject{{Name: "customer:acme:Admins"}},
+	})
+	defaultNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: pkgkey.DefaultNamespaceName}}
+	orgNamespace := test.NewOrgNamespace("acme")
+
+	k8sClientFake := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+		CtrlClient: clientfake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithRuntimeObjects(accessGroup).
+			WithStatusSubresource(&v1alpha1.AccessGroup{}).
+			Build(),
+		K8sClient: clientgofake.NewSimpleClientset(defaultNamespace, orgNamespace),
+	})
+
+	store := accessgroup.NewStore(accessgroup.AccessGroups{
+		WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+	})
+
+	userGroups, err := usergroups.New(usergroups.Config{
+		K8sClient:    k8sClientFake,
+		Logger:       microloggertest.New(),
+		AccessGroups: store,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	namespaceAuth, err := namespaceauth.New(namespaceauth.Config{
+		K8sClient:    k8sClientFake,
+		Logger:       microloggertest.New(),
+		AccessGroups: store,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := New(Config{
+		K8sClient:    k8sClientFake,
+		Logger:       microloggertest.New(),
+		AccessGroups: store,
+		// reconciles the consumers of the access groups like the controllers do
+		OnChange: func(ctx context.Context) error {
+			err := userGroups.EnsureCreated(ctx, defaultNamespace)
+			if err != nil {
+				return err
+			}
+			return namespaceAuth.EnsureCreated(ctx, orgNamespace)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = r.EnsureCreated(ctx, accessGroup)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	clusterRoleBinding, err := k8sClientFake.K8sClient().RbacV1().ClusterRoleBindings().Get(ctx, pkgkey.WriteAllCustomerGroupClusterRoleBindingName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected ClusterRoleBinding of the declared group, got error %v", err)
+	}
+	if !reflect.DeepEqual([]rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "customer:acme:Admins"}}, clusterRoleBinding.Subjects) {
+		t.Fatalf("Expected the declared group to be bound, got %v", clusterRoleBinding.Subjects)
+	}
+	_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings(orgNamespace.Name).Get(ctx, pkgkey.WriteAllCustomerGroupRoleBindingName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected RoleBinding of the declared group, got error %v", err)
+	}
+
+	err = r.EnsureDeleted(ctx, accessGroup)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	clusterRoleBindings, err := k8sClientFake.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, clusterRoleBinding := range clusterRoleBindings.Items {
+		if hasSubject(clusterRoleBinding.Subjects, "customer:acme:Admins") {
+			t.Fatalf("Expected the deleted group to be unbound, still bound by ClusterRoleBinding %s", clusterRoleBinding.Name)
+		}
+	}
+	for _, namespace := range []string{defaultNamespace.Name, orgNamespace.Name} {
+		roleBindings, err := k8sClientFake.K8sClient().RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, roleBinding := range roleBindings.Items {
+			if hasSubject(roleBinding.Subjects, "customer:acme:Admins") {
+				t.Fatalf("Expected the deleted group to be unbound, still bound by RoleBinding %s/%s", namespace, roleBinding.Name)
+			}
+		}
+	}
+}
+
+func hasSubject(subjects []rbacv1.Subject, name string) bool {
+	for _, subject := range subjects {
+		if subject.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func newAccessGroup(name string, spec v1alpha1.AccessGroupSpec) *v1alpha1.AccessGroup {
+	return &v1alpha1.AccessGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: spec,
+	}
+}
+
+func newClusterRoleBinding(name, clusterRole string, subject rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				label.ManagedBy: project.Name(),
+			},
+		},
+		Subjects: []rbacv1.Subject{subject},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+	}
+}
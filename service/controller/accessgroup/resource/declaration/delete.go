@@ -0,0 +1,23 @@
+package declaration
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	accessGroup, err := key.ToAccessGroup(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.updateDeclarations(ctx, accessGroup.Name)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// declaration package is responsible for adding the access tiers granted by
+// AccessGroup CRs to the access groups all other controllers bind, and for
+// reporting the bindings of their subjects in the AccessGroup status.
+package declaration
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/key"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+const (
+	Name = "declaration"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
+	// OnChange is called after the declared access groups changed, e.g. to
+	// requeue the objects of all controllers binding them.
+	OnChange func(ctx context.Context) error
+}
+
+type Resource struct {
+	k8sClient    k8sclient.Interface
+	logger       micrologger.Logger
+	accessGroups *accessgroup.Store
+	onChange     func(ctx context.Context) error
+}
+
+func New(config Config) (*Resource, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
+	if config.OnChange == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.OnChange must not be empty", config)
+	}
+
+	r := &Resource{
+		k8sClient:    config.K8sClient,
+		logger:       config.Logger,
+		accessGroups: config.AccessGroups,
+		onChange:     config.OnChange,
+	}
+
+	return r, nil
+}
+
+func (r Resource) K8sClient() kubernetes.Interface {
+	return r.k8sClient.K8sClient()
+}
+
+func (r Resource) Logger() micrologger.Logger {
+	return r.logger
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
+
+// ListDeclarations returns the access tiers granted by all AccessGroups except
+// the deleted one and the ones being deleted.
+func ListDeclarations(ctx context.Context, ctrlClient client.Client, deleted string) ([]accessgroup.Declaration, error) {
+	accessGroups := &v1alpha1.AccessGroupList{}
+	err := ctrlClient.List(ctx, accessGroups)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var declarations []accessgroup.Declaration
+	for _, accessGroup := range accessGroups.Items {
+		if accessGroup.Name == deleted || accessGroup.DeletionTimestamp != nil {
+			continue
+		}
+		declarations = append(declarations, key.ToDeclaration(accessGroup))
+	}
+
+	return declarations, nil
+}
+
+// updateDeclarations replaces the declared access groups and reconciles the
+// controllers binding them if they changed.
+func (r *Resource) updateDeclarations(ctx context.Context, deleted string) error {
+	declarations, err := ListDeclarations(ctx, r.k8sClient.CtrlClient(), deleted)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !r.accessGroups.SetDeclarations(declarations) {
+		return nil
+	}
+	r.logger.Debugf(ctx, "Declared access groups changed, requeueing all controllers")
+
+	err = r.onChange(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -14,11 +14,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
 type ClusterNamespaceConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	Protection protection.Policy
 }
 type ClusterNamespace struct {
 	*controller.Controller
@@ -8,6 +8,7 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace/resource/rbacappoperator"
 
 	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace/resource/clusternamespaceresources"
@@ -17,6 +18,8 @@ import (
 type clusterNamespaceResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	Protection protection.Policy
 }
 
 func newClusterNamespaceResources(config clusterNamespaceResourcesConfig) ([]resource.Interface, error) {
@@ -27,6 +30,8 @@ func newClusterNamespaceResources(config clusterNamespaceResourcesConfig) ([]res
 		c := clusternamespaceresources.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Protection: config.Protection,
 		}
 
 		clusterNamespaceResourcesResource, err = clusternamespaceresources.New(c)
@@ -53,6 +58,8 @@ func newClusterNamespaceResources(config clusterNamespaceResourcesConfig) ([]res
 		c := rbacappoperator.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Protection: config.Protection,
 		}
 
 		rbacAppOperatorResource, err = rbacappoperator.New(c)
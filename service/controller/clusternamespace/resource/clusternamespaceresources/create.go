@@ -141,7 +141,7 @@ func (r *Resource) ensureClusterNamespaceNSRoleBinding(ctx context.Context, subj
 		},
 	}
 
-	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, clusterNamespace, roleBinding); err != nil {
+	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, clusterNamespace, roleBinding); err != nil {
 		return microerror.Mask(err)
 	}
 
@@ -11,6 +11,8 @@ import (
 	"github.com/giantswarm/micrologger"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
 const (
@@ -20,11 +22,15 @@ const (
 type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	Protection protection.Policy
 }
 
 type Resource struct {
 	k8sClient k8sclient.Interface
 	logger    micrologger.Logger
+
+	protection protection.Policy
 }
 
 func (r Resource) K8sClient() kubernetes.Interface {
@@ -46,6 +52,8 @@ func New(config Config) (*Resource, error) {
 	r := &Resource{
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
+
+		protection: config.Protection.OrDefault(),
 	}
 
 	return r, nil
@@ -69,7 +69,7 @@ func (r *Resource) CreateCatalogReaderRoleAndBinding(ctx context.Context, ns cor
 
 	catalogReaderRoleBinding := getAppOperatorCatalogReaderRoleBinding(ns, catalogReaderRole)
 
-	if err := rbac.CreateOrUpdateRoleBinding(r, ctx, catalogReaderRoleBinding.Namespace, catalogReaderRoleBinding); err != nil {
+	if err := rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, catalogReaderRoleBinding.Namespace, catalogReaderRoleBinding); err != nil {
 		return microerror.Mask(err)
 	}
 
@@ -85,7 +85,7 @@ func (r *Resource) CreateOwnNamespaceRoleAndBinding(ctx context.Context, ns core
 
 	ownNamespaceRoleBinding := getAppOperatorOwnNamespaceRoleBinding(ns, ownNamespaceRole)
 
-	if err := rbac.CreateOrUpdateRoleBinding(r, ctx, ownNamespaceRoleBinding.Namespace, ownNamespaceRoleBinding); err != nil {
+	if err := rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, ownNamespaceRoleBinding.Namespace, ownNamespaceRoleBinding); err != nil {
 		return microerror.Mask(err)
 	}
 
@@ -5,6 +5,8 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
 const (
@@ -14,6 +16,8 @@ const (
 type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	Protection protection.Policy
 }
 
 func (r Resource) K8sClient() kubernetes.Interface {
@@ -27,6 +31,8 @@ func (r Resource) Logger() micrologger.Logger {
 type Resource struct {
 	k8sClient k8sclient.Interface
 	logger    micrologger.Logger
+
+	protection protection.Policy
 }
 
 func New(config Config) (*Resource, error) {
@@ -40,6 +46,8 @@ func New(config Config) (*Resource, error) {
 	r := &Resource{
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
+
+		protection: config.Protection.OrDefault(),
 	}
 
 	return r, nil
@@ -0,0 +1,66 @@
+package clusterrolebindingtemplate
+
+import (
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/controller"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+)
+
+type ClusterRoleBindingTemplateConfig struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	Protection protection.Policy
+}
+
+type ClusterRoleBindingTemplate struct {
+	*controller.Controller
+}
+
+func NewClusterRoleBindingTemplate(config ClusterRoleBindingTemplateConfig) (*ClusterRoleBindingTemplate, error) {
+	var err error
+
+	var resources []resource.Interface
+	{
+		c := clusterRoleBindingTemplateResourcesConfig(config)
+
+		resources, err = newClusterRoleBindingTemplateResources(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clusterRoleBindingTemplateController *controller.Controller
+	{
+		c := controller.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(v1alpha1.ClusterRoleBindingTemplate)
+			},
+			Resources: resources,
+
+			// Name is used to compute finalizer names. This here results in something
+			// like operatorkit.giantswarm.io/rbac-operator-clusterrolebindingtemplate-controller.
+			Name: project.Name() + "-clusterrolebindingtemplate-controller",
+		}
+
+		clusterRoleBindingTemplateController, err = controller.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	c := &ClusterRoleBindingTemplate{
+		Controller: clusterRoleBindingTemplateController,
+	}
+
+	return c, nil
+}
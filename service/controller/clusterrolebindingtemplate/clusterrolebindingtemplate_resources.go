@@ -0,0 +1,65 @@
+package clusterrolebindingtemplate
+
+import (
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/clusterrolebindingtemplate/resource/clusterrolebinding"
+)
+
+type clusterRoleBindingTemplateResourcesConfig struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	Protection protection.Policy
+}
+
+func newClusterRoleBindingTemplateResources(config clusterRoleBindingTemplateResourcesConfig) ([]resource.Interface, error) {
+	var err error
+
+	var clusterRoleBindingResource resource.Interface
+	{
+		c := clusterrolebinding.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			Protection: config.Protection,
+		}
+
+		clusterRoleBindingResource, err = clusterrolebinding.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	resources := []resource.Interface{
+		clusterRoleBindingResource,
+	}
+
+	{
+		c := retryresource.WrapConfig{
+			Logger: config.Logger,
+		}
+
+		resources, err = retryresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	{
+		c := metricsresource.WrapConfig{}
+
+		resources, err = metricsresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,22 @@
+package key
+
+import (
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+)
+
+func ToClusterRoleBindingTemplate(v interface{}) (v1alpha1.ClusterRoleBindingTemplate, error) {
+	if v == nil {
+		return v1alpha1.ClusterRoleBindingTemplate{}, microerror.Maskf(wrongTypeError, "expected non-nil, got %#v'", v)
+	}
+
+	p, ok := v.(*v1alpha1.ClusterRoleBindingTemplate)
+	if !ok {
+		return v1alpha1.ClusterRoleBindingTemplate{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", p, v)
+	}
+
+	c := p.DeepCopy()
+
+	return *c, nil
+}
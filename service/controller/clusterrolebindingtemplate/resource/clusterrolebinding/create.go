@@ -0,0 +1,183 @@
+package clusterrolebinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/controller/clusterrolebindingtemplate/key"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	template, err := key.ToClusterRoleBindingTemplate(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	organizations, err := r.getOrganizationsFromScope(ctx, template.Spec.Scopes)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	status := []string{}
+	for _, organization := range organizations {
+		clusterRoleBinding, err := getClusterRoleBindingFromTemplate(template, organization)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		clusterRoleBinding = cleanSubjects(r.protection, clusterRoleBinding, organization.Status.Namespace)
+		if len(clusterRoleBinding.Subjects) > 0 {
+			applied, err := r.ensureClusterRoleBinding(ctx, clusterRoleBinding)
+			if err != nil {
+				r.logger.Debugf(ctx, "Could not apply clusterRoleBinding %s due to error %v", clusterRoleBinding.Name, err)
+				continue
+			}
+			if applied {
+				status = append(status, clusterRoleBinding.Name)
+			}
+		}
+	}
+
+	// go through old list of cluster role bindings and compare for scope changes
+	for _, name := range template.Status.ClusterRoleBindings {
+		if !contains(status, name) {
+			if err = rbac.DeleteManagedClusterRoleBinding(r, ctx, name); err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	template.Status.ClusterRoleBindings = status
+	if err := r.k8sClient.CtrlClient().Status().Update(ctx, &template); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// ensureClusterRoleBinding creates or updates the ClusterRoleBinding unless a ClusterRoleBinding
+// with the same name exists which is not managed by rbac-operator, in which case it is left
+// untouched and false is returned. As the roleRef of a binding is immutable, the binding is
+// recreated when it references another role.
+func (r *Resource) ensureClusterRoleBinding(ctx context.Context, clusterRoleBinding *rbacv1.ClusterRoleBinding) (bool, error) {
+	existing, err := r.k8sClient.K8sClient().RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// the binding is created below
+	} else if err != nil {
+		return false, microerror.Mask(err)
+	} else if existing.Labels[label.ManagedBy] != project.Name() {
+		r.logger.Debugf(ctx, "Skipping clusterRoleBinding %s, as it is not managed by %s", clusterRoleBinding.Name, project.Name())
+		return false, nil
+	} else if !pause.IsPaused(existing) && existing.RoleRef != clusterRoleBinding.RoleRef {
+		r.logger.Debugf(ctx, "Recreating clusterRoleBinding %s, as its roleRef changed", clusterRoleBinding.Name)
+		err = rbac.DeleteClusterRoleBinding(r, ctx, clusterRoleBinding.Name)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	err = rbac.CreateOrUpdateClusterRoleBinding(r, ctx, clusterRoleBinding)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+func getClusterRoleBindingFromTemplate(template v1alpha1.ClusterRoleBindingTemplate, organization security.Organization) (*rbacv1.ClusterRoleBinding, error) {
+	objectMeta := template.Spec.Template.ObjectMeta
+	{
+		// ensure per-organization name, cluster role bindings are not namespaced
+		objectMeta.Name = getClusterRoleBindingNameFromTemplate(template, organization.Name)
+		objectMeta.Namespace = ""
+		// add labels and annotations
+		labels := map[string]string{}
+		for k, v := range objectMeta.GetLabels() {
+			labels[k] = v
+		}
+		labels[label.ManagedBy] = project.Name()
+		labels[label.Organization] = organization.Name
+		objectMeta.SetLabels(labels)
+		annotations := map[string]string{}
+		for k, v := range objectMeta.GetAnnotations() {
+			annotations[k] = v
+		}
+		if annotations[annotation.Notes] == "" {
+			annotations[annotation.Notes] = fmt.Sprintf("Generated based on ClusterRoleBindingTemplate %s", template.Name)
+		}
+		objectMeta.SetAnnotations(annotations)
+	}
+
+	// ensure role reference
+	roleRef := template.Spec.Template.RoleRef
+	{
+		if incompleteRoleRef(roleRef) {
+			return nil, microerror.Maskf(invalidConfigError, "ClusterRoleBindingTemplate %s has incomplete roleRef %v", template.Name, roleRef)
+		}
+		if roleRef.APIGroup == "" {
+			roleRef.APIGroup = "rbac.authorization.k8s.io"
+		}
+	}
+
+	// ensure subjects
+	var subjects []rbacv1.Subject
+	{
+		for _, subject := range template.Spec.Template.Subjects {
+			if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+				subject.Namespace = organization.Status.Namespace
+			}
+			subjects = append(subjects, subject)
+		}
+	}
+
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: objectMeta,
+		RoleRef:    roleRef,
+		Subjects:   subjects,
+	}, nil
+}
+
+// cleanSubjects removes the subjects which may not be bound for the organization according to the
+// protection policy of its namespace
+func cleanSubjects(policy protection.Policy, clusterRoleBinding *rbacv1.ClusterRoleBinding, orgNamespace string) *rbacv1.ClusterRoleBinding {
+	clusterRoleBinding.Subjects = policy.FilterSubjects(orgNamespace, clusterRoleBinding.Subjects)
+	return clusterRoleBinding
+}
+
+// incompleteRoleRef reports whether the roleRef cannot be bound cluster-wide.
+// Namespaced Roles cannot be referenced from a ClusterRoleBinding.
+func incompleteRoleRef(roleRef rbacv1.RoleRef) bool {
+	if roleRef.Name == "" {
+		return true
+	}
+	if roleRef.Kind != "ClusterRole" {
+		return true
+	}
+	return false
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
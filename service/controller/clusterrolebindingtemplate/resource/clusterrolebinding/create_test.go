@@ -0,0 +1,436 @@
+package clusterrolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/defaultnamespacetest"
+)
+
+func TestGetClusterRoleBindingFromTemplate(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Template     v1alpha1.ClusterRoleBindingTemplateResource
+		TemplateName string
+		Organization string
+
+		expectedClusterRoleBinding *rbacv1.ClusterRoleBinding
+		expectError                bool
+	}{
+		{
+			Name: "case0: add defaults",
+			Template: v1alpha1.ClusterRoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{
+					Name: "read-releases",
+					Kind: "ClusterRole",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+					{Kind: "ServiceAccount", Name: "automation"},
+				},
+			},
+			TemplateName: "releases",
+			Organization: "example",
+
+			expectedClusterRoleBinding: getTestClusterRoleBinding("releases-example", "example", "releases"),
+		},
+		{
+			Name: "case1: forbidden roleRef kind",
+			Template: v1alpha1.ClusterRoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{
+					Name: "read-releases",
+					Kind: "Role",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			TemplateName: "releases",
+			Organization: "example",
+
+			expectError: true,
+		},
+		{
+			Name: "case2: no roleRef",
+			Template: v1alpha1.ClusterRoleBindingTemplateResource{
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			TemplateName: "releases",
+			Organization: "example",
+
+			expectError: true,
+		},
+		{
+			Name: "case3: retain values and use binding name from template metadata",
+			Template: v1alpha1.ClusterRoleBindingTemplateResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "releases-read",
+					Labels: map[string]string{
+						"the-label": "the-value",
+					},
+					Annotations: map[string]string{
+						annotation.Notes: "There is already a note here",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					Name:     "read-releases",
+					Kind:     "ClusterRole",
+					APIGroup: "rbac.authorization.k8s.io",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+					{Kind: "ServiceAccount", Name: "automation", Namespace: "org-other"},
+				},
+			},
+			TemplateName: "releases",
+			Organization: "example",
+
+			expectedClusterRoleBinding: &rbacv1.ClusterRoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ClusterRoleBinding",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "releases-read-example",
+					Labels: map[string]string{
+						"the-label":        "the-value",
+						label.ManagedBy:    project.Name(),
+						label.Organization: "example",
+					},
+					Annotations: map[string]string{
+						annotation.Notes: "There is already a note here",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					Name:     "read-releases",
+					Kind:     "ClusterRole",
+					APIGroup: "rbac.authorization.k8s.io",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+					{Kind: "ServiceAccount", Name: "automation", Namespace: "org-other"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := v1alpha1.ClusterRoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tc.TemplateName,
+				},
+				Spec: v1alpha1.ClusterRoleBindingTemplateSpec{
+					Template: tc.Template,
+				},
+			}
+
+			result, err := getClusterRoleBindingFromTemplate(template, *getTestOrganization(tc.Organization))
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if tc.expectError && err == nil {
+				t.Fatalf("Expected error, got success")
+			}
+
+			if !tc.expectError && !reflect.DeepEqual(tc.expectedClusterRoleBinding, result) {
+				t.Fatalf("Expected\n%v\n\n...to be equal to:\n%v\n", result, tc.expectedClusterRoleBinding)
+			}
+		})
+	}
+}
+
+func TestEnsureCreated(t *testing.T) {
+	testCases := []struct {
+		Name                        string
+		Template                    *v1alpha1.ClusterRoleBindingTemplate
+		Organizations               []string
+		ExistingClusterRoleBindings []runtime.Object
+
+		expectedClusterRoleBindings []*rbacv1.ClusterRoleBinding
+		expectedStatus              []string
+	}{
+		{
+			Name:          "case0: create cluster role binding for all orgs",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, nil),
+			Organizations: []string{"example", "example-2"},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+				getTestClusterRoleBinding("releases-example-2", "example-2", "releases"),
+			},
+			expectedStatus: []string{"releases-example", "releases-example-2"},
+		},
+		{
+			Name: "case1: create cluster role binding for one org",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example"},
+			}, nil),
+			Organizations: []string{"example", "example-2"},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+			},
+			expectedStatus: []string{"releases-example"},
+		},
+		{
+			Name: "case2: remove cluster role binding of orgs that are out of scope",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example"},
+			}, []string{"releases-example", "releases-example-2"}),
+			Organizations: []string{"example", "example-2"},
+			ExistingClusterRoleBindings: []runtime.Object{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+				getTestClusterRoleBinding("releases-example-2", "example-2", "releases"),
+			},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+			},
+			expectedStatus: []string{"releases-example"},
+		},
+		{
+			Name:          "case3: only keep valid subjects for protected org",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, nil),
+			Organizations: []string{"giantswarm"},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "releases-giantswarm",
+					},
+					Subjects: []rbacv1.Subject{
+						{Kind: "ServiceAccount", Name: "automation", Namespace: "org-giantswarm"},
+					},
+				},
+			},
+			expectedStatus: []string{"releases-giantswarm"},
+		},
+		{
+			Name:          "case4: do not overwrite cluster role binding not managed by rbac-operator",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, nil),
+			Organizations: []string{"example", "example-2"},
+			ExistingClusterRoleBindings: []runtime.Object{
+				getUnmanagedClusterRoleBinding("releases-example"),
+			},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getUnmanagedClusterRoleBinding("releases-example"),
+				getTestClusterRoleBinding("releases-example-2", "example-2", "releases"),
+			},
+			expectedStatus: []string{"releases-example-2"},
+		},
+		{
+			Name: "case5: do not delete cluster role binding not managed by rbac-operator",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example"},
+			}, []string{"releases-example", "releases-example-2"}),
+			Organizations: []string{"example", "example-2"},
+			ExistingClusterRoleBindings: []runtime.Object{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+				getUnmanagedClusterRoleBinding("releases-example-2"),
+			},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+				getUnmanagedClusterRoleBinding("releases-example-2"),
+			},
+			expectedStatus: []string{"releases-example"},
+		},
+		{
+			Name:          "case6: recreate cluster role binding when the roleRef changed",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, []string{"releases-example"}),
+			Organizations: []string{"example"},
+			ExistingClusterRoleBindings: []runtime.Object{
+				func() *rbacv1.ClusterRoleBinding {
+					clusterRoleBinding := getTestClusterRoleBinding("releases-example", "example", "releases")
+					clusterRoleBinding.RoleRef.Name = "read-all"
+					return clusterRoleBinding
+				}(),
+			},
+
+			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				getTestClusterRoleBinding("releases-example", "example", "releases"),
+			},
+			expectedStatus: []string{"releases-example"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			objects := []runtime.Object{tc.Template}
+			namespaces := []runtime.Object{}
+			for _, org := range tc.Organizations {
+				objects = append(objects, getTestOrganization(org))
+				namespaces = append(namespaces, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "org-" + org,
+					},
+				})
+			}
+			namespaces = append(namespaces, tc.ExistingClusterRoleBindings...)
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(objects...).
+						WithStatusSubresource(&v1alpha1.ClusterRoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(namespaces...),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, tc.Template)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			clusterRoleBindingList, err := k8sClientFake.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to get cluster role bindings: %s", err)
+			}
+			defaultnamespacetest.ClusterRoleBindingsShouldEqual(t, tc.expectedClusterRoleBindings, clusterRoleBindingList.Items)
+			for _, expected := range tc.expectedClusterRoleBindings {
+				if expected.RoleRef.Name == "" {
+					continue
+				}
+				for _, actual := range clusterRoleBindingList.Items {
+					if actual.Name == expected.Name && actual.RoleRef != expected.RoleRef {
+						t.Fatalf("Expected roleRef %v of cluster role binding %s, got %v", expected.RoleRef, actual.Name, actual.RoleRef)
+					}
+				}
+			}
+
+			template := &v1alpha1.ClusterRoleBindingTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: tc.Template.Name}, template)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expectedStatus, template.Status.ClusterRoleBindings) {
+				t.Fatalf("Expected status %v, got %v", tc.expectedStatus, template.Status.ClusterRoleBindings)
+			}
+		})
+	}
+}
+
+func getTestTemplate(selector v1alpha1.ScopeSelector, status []string) *v1alpha1.ClusterRoleBindingTemplate {
+	return &v1alpha1.ClusterRoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "releases",
+		},
+		Spec: v1alpha1.ClusterRoleBindingTemplateSpec{
+			Template: v1alpha1.ClusterRoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{
+					Name: "read-releases",
+					Kind: "ClusterRole",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+					{Kind: "ServiceAccount", Name: "automation"},
+				},
+			},
+			Scopes: v1alpha1.ClusterRoleBindingTemplateScopes{
+				OrganizationSelector: selector,
+			},
+		},
+		Status: v1alpha1.ClusterRoleBindingTemplateStatus{
+			ClusterRoleBindings: status,
+		},
+	}
+}
+
+func getTestOrganization(name string) *security.Organization {
+	return &security.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"name": name,
+			},
+		},
+		Status: security.OrganizationStatus{
+			Namespace: "org-" + name,
+		},
+	}
+}
+
+func getTestClusterRoleBinding(name, organization, templateName string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				label.ManagedBy:    project.Name(),
+				label.Organization: organization,
+			},
+			Annotations: map[string]string{
+				annotation.Notes: "Generated based on ClusterRoleBindingTemplate " + templateName,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     "read-releases",
+			Kind:     "ClusterRole",
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: "Group", Name: "test-group"},
+			{Kind: "ServiceAccount", Name: "automation", Namespace: "org-" + organization},
+		},
+	}
+}
+
+func getUnmanagedClusterRoleBinding(name string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     "cluster-admin",
+			Kind:     "ClusterRole",
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: "Group", Name: "platform-team"},
+		},
+	}
+}
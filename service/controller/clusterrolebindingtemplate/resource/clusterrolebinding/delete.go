@@ -0,0 +1,25 @@
+package clusterrolebinding
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/controller/clusterrolebindingtemplate/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	template, err := key.ToClusterRoleBindingTemplate(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, name := range template.Status.ClusterRoleBindings {
+		if err = rbac.DeleteManagedClusterRoleBinding(r, ctx, name); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
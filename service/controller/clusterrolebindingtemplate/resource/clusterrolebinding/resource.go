@@ -0,0 +1,121 @@
+// clusterrolebinding package is responsible for creating clusterrolebindings based on clusterRoleBindingTemplate CRs
+// this allows for the dynamic granting of cluster-scoped permissions per organization
+package clusterrolebinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+)
+
+const (
+	Name = "clusterrolebinding"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	Protection protection.Policy
+}
+
+type Resource struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+
+	protection protection.Policy
+}
+
+func New(config Config) (*Resource, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	r := &Resource{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		protection: config.Protection.OrDefault(),
+	}
+
+	return r, nil
+}
+
+func (r Resource) K8sClient() kubernetes.Interface {
+	return r.k8sClient.K8sClient()
+}
+
+func (r Resource) Logger() micrologger.Logger {
+	return r.logger
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
+
+func getLabelSelectorFromScopes(scopes v1alpha1.ClusterRoleBindingTemplateScopes) (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      scopes.OrganizationSelector.MatchLabels,
+		MatchExpressions: scopes.OrganizationSelector.MatchExpressions,
+	})
+}
+
+// getOrganizationsFromScope returns the organizations in scope which have an org namespace
+// that exists and is not being deleted.
+func (r *Resource) getOrganizationsFromScope(ctx context.Context, scopes v1alpha1.ClusterRoleBindingTemplateScopes) ([]security.Organization, error) {
+	labelSelector, err := getLabelSelectorFromScopes(scopes)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	organizations := &security.OrganizationList{}
+	if err := r.k8sClient.CtrlClient().List(ctx, organizations, &client.ListOptions{LabelSelector: labelSelector}); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.logger.Debugf(ctx, "No organizations in organization scope %s", labelSelector.String())
+			return []security.Organization{}, nil
+		}
+		return nil, microerror.Mask(err)
+	}
+
+	scope := []security.Organization{}
+	for _, o := range organizations.Items {
+		if o.Status.Namespace == "" {
+			continue
+		}
+		namespace, err := r.k8sClient.K8sClient().CoreV1().Namespaces().Get(ctx, o.Status.Namespace, metav1.GetOptions{})
+		if err != nil {
+			continue
+		} else if namespace.DeletionTimestamp != nil {
+			continue
+		}
+		scope = append(scope, o)
+	}
+	if len(scope) == 0 {
+		r.logger.Debugf(ctx, "No organizations in organization scope %s", labelSelector.String())
+	}
+
+	return scope, nil
+}
+
+func getClusterRoleBindingNameFromTemplate(template v1alpha1.ClusterRoleBindingTemplate, organization string) string {
+	clusterRoleBindingName := template.Spec.Template.Name
+	if clusterRoleBindingName == "" {
+		clusterRoleBindingName = template.Name
+	}
+	return fmt.Sprintf("%s-%s", clusterRoleBindingName, organization)
+}
@@ -13,20 +13,23 @@ import (
 
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
 )
 
 type CrossplaneConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
-	CustomerReaderGroups                []accessgroup.AccessGroup
+	AccessGroups                        *accessgroup.Store
 	CrossplaneBindTriggeringClusterRole string
 }
 
 type Crossplane struct {
 	ClusterRoleController *controller.Controller
 	NamespaceController   *controller.Controller
+
+	crossplaneBindTriggeringClusterRole string
+	k8sClient                           k8sclient.Interface
 }
 
 func NewCrossplane(config CrossplaneConfig) (*Crossplane, error) {
@@ -66,7 +69,6 @@ func NewCrossplane(config CrossplaneConfig) (*Crossplane, error) {
 		crossplaneNamespace, err := NewCrossplaneNamespace(CrossplaneNamespaceConfig{
 			K8sClient:                           config.K8sClient,
 			Logger:                              config.Logger,
-			CustomerAdminGroups:                 config.CustomerAdminGroups,
 			CrossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 		})
 		if err != nil {
@@ -78,11 +80,28 @@ func NewCrossplane(config CrossplaneConfig) (*Crossplane, error) {
 	c := &Crossplane{
 		ClusterRoleController: clusterRoleAuthController,
 		NamespaceController:   namespaceController,
+
+		crossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
+		k8sClient:                           config.K8sClient,
 	}
 
 	return c, nil
 }
 
+// Resync requeues the ClusterRole triggering the crossplane ClusterRoleBinding,
+// e.g. after the access groups changed.
+func (c *Crossplane) Resync(ctx context.Context) error {
+	clusterRole := &rbacv1.ClusterRole{}
+	clusterRole.Name = c.crossplaneBindTriggeringClusterRole
+
+	err := resync.Enqueue(ctx, c.k8sClient.CtrlClient(), clusterRole)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
 func (c *Crossplane) Boot(ctx context.Context) error {
 	go c.ClusterRoleController.Boot(ctx)
 	go c.NamespaceController.Boot(ctx)
@@ -14,14 +14,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/rbac-operator/pkg/project"
-	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
 
 type CrossplaneNamespaceConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
 	CrossplaneBindTriggeringClusterRole string
 }
 
@@ -38,7 +36,6 @@ func NewCrossplaneNamespace(config CrossplaneNamespaceConfig) (*CrossplaneNamesp
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			CustomerAdminGroups:                 config.CustomerAdminGroups,
 			CrossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 		}
 
@@ -9,14 +9,12 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 
 	"github.com/giantswarm/rbac-operator/service/controller/crossplane/resource/crossplanenamespace"
-	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
 
 type crossplaneNamespaceResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
 	CrossplaneBindTriggeringClusterRole string
 }
 
@@ -29,7 +27,6 @@ func newCrossplaneNamespaceResources(config crossplaneNamespaceResourcesConfig)
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			CustomerAdminGroups:                 config.CustomerAdminGroups,
 			CrossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 		}
 
@@ -16,8 +16,7 @@ type crossplaneResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
-	CustomerReaderGroups                []accessgroup.AccessGroup
+	AccessGroups                        *accessgroup.Store
 	CrossplaneBindTriggeringClusterRole string
 }
 
@@ -30,7 +29,7 @@ func newCrossplaneResources(config crossplaneResourcesConfig) ([]resource.Interf
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			CustomerAdminGroups:                 config.CustomerAdminGroups,
+			AccessGroups:                        config.AccessGroups,
 			CrossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 		}
 
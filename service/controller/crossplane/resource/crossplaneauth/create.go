@@ -16,6 +16,7 @@ import (
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	pkgrbac "github.com/giantswarm/rbac-operator/pkg/rbac"
 	"github.com/giantswarm/rbac-operator/service/controller/crossplane/key"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
 
 func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
@@ -42,14 +43,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	}
 	subjects = append(subjects, orgAutomationSAs...)
 
-	for _, group := range r.customerAdminGroups {
-		subjects = append(subjects, rbacv1.Subject{
-			APIGroup:  "rbac.authorization.k8s.io",
-			Kind:      "Group",
-			Name:      group.Name,
-			Namespace: pkgkey.DefaultNamespaceName,
-		})
-	}
+	subjects = append(subjects, accessgroup.GroupsToSubjects(r.accessGroups.Get().WriteAllCustomerGroups)...)
 
 	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
 		TypeMeta: metav1.TypeMeta{
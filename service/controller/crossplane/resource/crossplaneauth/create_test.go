@@ -10,6 +10,7 @@ import (
 
 	"github.com/giantswarm/rbac-operator/service/controller/crossplane/key"
 	"github.com/giantswarm/rbac-operator/service/controller/crossplane/resource/crossplaneauth"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -57,6 +58,7 @@ func Test_EnsureCreated(t *testing.T) {
 			fakeCrossplaneauth, err := crossplaneauth.New(crossplaneauth.Config{
 				K8sClient:                           k8sClientFake,
 				Logger:                              microloggertest.New(),
+				AccessGroups:                        accessgroup.NewStore(accessgroup.AccessGroups{}),
 				CrossplaneBindTriggeringClusterRole: testCrossplaneClusterRoleName,
 			})
 			if err != nil {
@@ -22,14 +22,14 @@ type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
+	AccessGroups                        *accessgroup.Store
 	CrossplaneBindTriggeringClusterRole string
 }
 
 type Resource struct {
 	k8sClient                           k8sclient.Interface
 	logger                              micrologger.Logger
-	customerAdminGroups                 []accessgroup.AccessGroup
+	accessGroups                        *accessgroup.Store
 	crossplaneBindTriggeringClusterRole string
 }
 
@@ -40,11 +40,14 @@ func New(config Config) (*Resource, error) {
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
 
 	r := &Resource{
 		k8sClient:                           config.K8sClient,
 		logger:                              config.Logger,
-		customerAdminGroups:                 config.CustomerAdminGroups,
+		accessGroups:                        config.AccessGroups,
 		crossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 	}
 
@@ -7,9 +7,6 @@ import (
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
-
-	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
-
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -21,14 +18,12 @@ type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups                 []accessgroup.AccessGroup
 	CrossplaneBindTriggeringClusterRole string
 }
 
 type Resource struct {
 	k8sClient                           k8sclient.Interface
 	logger                              micrologger.Logger
-	customerAdminGroups                 []accessgroup.AccessGroup
 	crossplaneBindTriggeringClusterRole string
 }
 
@@ -47,7 +42,6 @@ func New(config Config) (*Resource, error) {
 	r := &Resource{
 		k8sClient:                           config.K8sClient,
 		logger:                              config.Logger,
-		customerAdminGroups:                 config.CustomerAdminGroups,
 		crossplaneBindTriggeringClusterRole: config.CrossplaneBindTriggeringClusterRole,
 	}
 
@@ -16,23 +16,26 @@ import (
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
 	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/resource/clusterroles"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
 )
 
 type DefaultNamespaceConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups  []accessgroup.AccessGroup
-	CustomerReaderGroups []accessgroup.AccessGroup
-	GSAdminGroups        []accessgroup.AccessGroup
+	AccessGroups *accessgroup.Store
+	Protection   protection.Policy
 
 	Provider string
 }
 type DefaultNamespace struct {
-	Controller *controller.Controller
-	k8sClient  k8sclient.Interface
-	resources  []resource.Interface
+	Controller   *controller.Controller
+	k8sClient    k8sclient.Interface
+	resources    []resource.Interface
+	clusterRoles *clusterroles.Resource
 }
 
 func NewDefaultNamespace(config DefaultNamespaceConfig) (*DefaultNamespace, error) {
@@ -49,6 +52,22 @@ func NewDefaultNamespace(config DefaultNamespaceConfig) (*DefaultNamespace, erro
 		}
 	}
 
+	// clusterRoles is used to update the ClusterRoles derived from API discovery
+	// without reconciling all resources.
+	var clusterRoles *clusterroles.Resource
+	{
+		c := clusterroles.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			Provider:  config.Provider,
+		}
+
+		clusterRoles, err = clusterroles.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var defaultNamespaceController *controller.Controller
 	{
 		//kubernetes.io/metadata.name: default
@@ -79,9 +98,10 @@ func NewDefaultNamespace(config DefaultNamespaceConfig) (*DefaultNamespace, erro
 	}
 
 	c := &DefaultNamespace{
-		Controller: defaultNamespaceController,
-		k8sClient:  config.K8sClient,
-		resources:  resources,
+		Controller:   defaultNamespaceController,
+		k8sClient:    config.K8sClient,
+		resources:    resources,
+		clusterRoles: clusterRoles,
 	}
 
 	return c, nil
@@ -100,3 +120,27 @@ func (c *DefaultNamespace) EnsureResourcesCreated(ctx context.Context) error {
 	}
 	return nil
 }
+
+// Resync requeues the default namespace, e.g. after the access groups changed.
+func (c *DefaultNamespace) Resync(ctx context.Context) error {
+	namespace := &corev1.Namespace{}
+	namespace.Name = pkgkey.DefaultNamespaceName
+
+	err := resync.Enqueue(ctx, c.k8sClient.CtrlClient(), namespace)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// EnsureDiscoveryClusterRolesCreated updates the ClusterRoles derived from API
+// discovery, e.g. 'read-all' after CustomResourceDefinitions were installed.
+func (c *DefaultNamespace) EnsureDiscoveryClusterRolesCreated(ctx context.Context) error {
+	err := c.clusterRoles.EnsureDiscoveryClusterRoles(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/resource/automationsa"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/resource/catalog"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/resource/clusternamespace"
@@ -22,9 +23,8 @@ type defaultNamespaceBootstrapResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups  []accessgroup.AccessGroup
-	CustomerReaderGroups []accessgroup.AccessGroup
-	GSAdminGroups        []accessgroup.AccessGroup
+	AccessGroups *accessgroup.Store
+	Protection   protection.Policy
 
 	Provider string
 }
@@ -52,6 +52,8 @@ func newDefaultNamespaceResources(config defaultNamespaceBootstrapResourcesConfi
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 			Provider:  config.Provider,
+
+			Protection: config.Protection,
 		}
 
 		automationSAResource, err = automationsa.New(c)
@@ -66,10 +68,9 @@ func newDefaultNamespaceResources(config defaultNamespaceBootstrapResourcesConfi
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			CustomerAdminGroups:  config.CustomerAdminGroups,
-			CustomerReaderGroups: config.CustomerReaderGroups,
-			GSAdminGroups:        config.GSAdminGroups,
-			Provider:             config.Provider,
+			AccessGroups: config.AccessGroups,
+			Protection:   config.Protection,
+			Provider:     config.Provider,
 		}
 
 		userGroupsResource, err = usergroups.New(c)
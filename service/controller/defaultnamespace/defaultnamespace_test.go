@@ -38,7 +38,7 @@ func Test_DefaultNamespaceController(t *testing.T) {
 			ExpectedClusterRoles:         11,
 			ExpectedClusterRoleBindings:  11,
 			ExpectedRoles:                1,
-			ExpectedRoleBindings:         2,
+			ExpectedRoleBindings:         1,
 			ExpectedRoleBindingTemplates: 3,
 		},
 		{
@@ -49,7 +49,7 @@ func Test_DefaultNamespaceController(t *testing.T) {
 			ExpectedClusterRoles:         10,
 			ExpectedClusterRoleBindings:  9,
 			ExpectedRoles:                1,
-			ExpectedRoleBindings:         2,
+			ExpectedRoleBindings:         1,
 			ExpectedRoleBindingTemplates: 3,
 		},
 	}
@@ -88,12 +88,14 @@ func Test_DefaultNamespaceController(t *testing.T) {
 			}
 
 			defaultNamespaceController, err := NewDefaultNamespace(DefaultNamespaceConfig{
-				K8sClient:            k8sClientFake,
-				Logger:               microloggertest.New(),
-				CustomerAdminGroups:  tc.CustomerAdminGroups,
-				CustomerReaderGroups: tc.CustomerAdminGroups,
-				GSAdminGroups:        tc.GSAdminGroup,
-				Provider:             tc.Provider,
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+				AccessGroups: accessgroup.NewStore(accessgroup.AccessGroups{
+					WriteAllCustomerGroups:   tc.CustomerAdminGroups,
+					ReadAllCustomerGroups:    tc.CustomerAdminGroups,
+					WriteAllGiantswarmGroups: tc.GSAdminGroup,
+				}),
+				Provider: tc.Provider,
 			})
 
 			if err != nil {
@@ -153,7 +153,7 @@ func (r *Resource) createWriteAllRoleBindingToAutomationSA(ctx context.Context,
 		},
 	}
 
-	return rbac.CreateOrUpdateRoleBinding(r, ctx, namespace, writeAllRoleBinding)
+	return rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, namespace, writeAllRoleBinding)
 }
 
 // Ensures the ClusterRoleBinding 'write-organizations-customer-sa' between
@@ -5,6 +5,8 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
 const (
@@ -15,12 +17,16 @@ type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 	Provider  string
+
+	Protection protection.Policy
 }
 
 type Resource struct {
 	k8sClient k8sclient.Interface
 	logger    micrologger.Logger
 	provider  string
+
+	protection protection.Policy
 }
 
 func (r Resource) K8sClient() kubernetes.Interface {
@@ -43,6 +49,8 @@ func New(config Config) (*Resource, error) {
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
 		provider:  config.Provider,
+
+		protection: config.Protection.OrDefault(),
 	}
 
 	return r, nil
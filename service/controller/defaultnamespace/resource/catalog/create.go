@@ -2,17 +2,16 @@ package catalog
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/giantswarm/k8smetadata/pkg/annotation"
 	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	rbacv1 "k8s.io/api/rbac/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
 	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/key"
 )
 
@@ -56,28 +55,8 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		},
 	}
 
-	_, err = r.K8sClient().RbacV1().Roles(role.Namespace).Get(ctx, role.Name, metav1.GetOptions{})
-	if apierrors.IsNotFound(err) {
-		r.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("creating role %#q in namespace %s", role.Name, role.Namespace))
-
-		_, err := r.K8sClient().RbacV1().Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{})
-		if apierrors.IsAlreadyExists(err) {
-			// do nothing
-		} else if err != nil {
-			return microerror.Mask(err)
-		}
-
-		r.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("role %#q in namespace %s has been created", role.Name, role.Namespace))
-
-	} else if err != nil {
+	if err = rbac.CreateOrUpdateRole(r, ctx, role.Namespace, role); err != nil {
 		return microerror.Mask(err)
-	} else {
-		r.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating role %#q in namespace %s", role.Name, role.Namespace))
-		_, err := r.K8sClient().RbacV1().Roles(role.Namespace).Update(ctx, role, metav1.UpdateOptions{})
-		if err != nil {
-			return microerror.Mask(err)
-		}
-		r.Logger().LogCtx(ctx, "level", "info", "message", fmt.Sprintf("role %#q in namespace %s has been updated", role.Name, role.Namespace))
 	}
 
 	return nil
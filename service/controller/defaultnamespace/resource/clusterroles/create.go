@@ -76,6 +76,18 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
+// EnsureDiscoveryClusterRoles ensures the ClusterRoles derived from API
+// discovery, e.g. after CustomResourceDefinitions or APIServices changed.
+// Currently this is only 'read-all'.
+func (r *Resource) EnsureDiscoveryClusterRoles(ctx context.Context) error {
+	err := r.createReadAllClusterRole(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
 // Ensures the ClusterRole 'read-all'.
 //
 // Purpose of this role is to enable read permissions (get, list, watch)
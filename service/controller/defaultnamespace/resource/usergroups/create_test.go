@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger/microloggertest"
 	security "github.com/giantswarm/organization-operator/api/v1alpha1"
@@ -17,6 +18,8 @@ import (
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/defaultnamespacetest"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
@@ -28,6 +31,7 @@ func Test_UserGroups(t *testing.T) {
 		InitialObjects              []runtime.Object
 		CustomerAdminGroups         []accessgroup.AccessGroup
 		GSAdminGroups               []accessgroup.AccessGroup
+		AccessTiers                 []accessgroup.AccessTier
 		ExpectedRoleBindings        []*rbacv1.RoleBinding
 		ExpectedClusterRoleBindings []*rbacv1.ClusterRoleBinding
 		ExpectedError               error
@@ -37,13 +41,6 @@ func Test_UserGroups(t *testing.T) {
 			Provider:            "capa",
 			CustomerAdminGroups: []accessgroup.AccessGroup{{Name: "customers1"}, {Name: "customers2"}},
 			GSAdminGroups:       []accessgroup.AccessGroup{{Name: "giantswarm1"}, {Name: "giantswarm2"}},
-			ExpectedRoleBindings: []*rbacv1.RoleBinding{
-				defaultnamespacetest.NewRoleBinding(
-					pkgkey.WriteAllCustomerGroupRoleBindingName(),
-					pkgkey.DefaultNamespaceName,
-					defaultnamespacetest.NewGroupSubjects("customers1", "customers2"),
-				),
-			},
 			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
 				defaultnamespacetest.NewClusterRoleBinding(
 					pkgkey.WriteOrganizationsCustomerGroupClusterRoleBindingName(),
@@ -71,9 +68,10 @@ func Test_UserGroups(t *testing.T) {
 			Name:     "case 1: Add multiple subjects to existing bindings on CAPA",
 			Provider: "capa",
 			InitialObjects: []runtime.Object{
-				defaultnamespacetest.NewRoleBinding(
+				// bound cluster-admin in the default namespace before, which
+				// the ClusterRoleBinding already grants
+				newManagedRoleBinding(
 					pkgkey.WriteAllCustomerGroupRoleBindingName(),
-					pkgkey.DefaultNamespaceName,
 					defaultnamespacetest.NewGroupSubjects("customers"),
 				),
 				defaultnamespacetest.NewClusterRoleBinding(
@@ -91,13 +89,6 @@ func Test_UserGroups(t *testing.T) {
 			},
 			CustomerAdminGroups: []accessgroup.AccessGroup{{Name: "customers1"}, {Name: "customers2"}},
 			GSAdminGroups:       []accessgroup.AccessGroup{{Name: "giantswarm1"}, {Name: "giantswarm2"}},
-			ExpectedRoleBindings: []*rbacv1.RoleBinding{
-				defaultnamespacetest.NewRoleBinding(
-					pkgkey.WriteAllCustomerGroupRoleBindingName(),
-					pkgkey.DefaultNamespaceName,
-					defaultnamespacetest.NewGroupSubjects("customers1", "customers2"),
-				),
-			},
 			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
 				defaultnamespacetest.NewClusterRoleBinding(
 					pkgkey.WriteOrganizationsCustomerGroupClusterRoleBindingName(),
@@ -130,13 +121,6 @@ func Test_UserGroups(t *testing.T) {
 			Provider:            "capz",
 			CustomerAdminGroups: []accessgroup.AccessGroup{{Name: "customers1"}, {Name: "customers2"}},
 			GSAdminGroups:       []accessgroup.AccessGroup{{Name: "giantswarm1"}, {Name: "giantswarm2"}},
-			ExpectedRoleBindings: []*rbacv1.RoleBinding{
-				defaultnamespacetest.NewRoleBinding(
-					pkgkey.WriteAllCustomerGroupRoleBindingName(),
-					pkgkey.DefaultNamespaceName,
-					defaultnamespacetest.NewGroupSubjects("customers1", "customers2"),
-				),
-			},
 			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
 				defaultnamespacetest.NewClusterRoleBinding(
 					pkgkey.WriteOrganizationsCustomerGroupClusterRoleBindingName(),
@@ -156,6 +140,63 @@ func Test_UserGroups(t *testing.T) {
 				),
 			},
 		},
+		{
+			Name:     "case 4: Bind the ClusterRoles of access tiers and remove bindings of removed tiers",
+			Provider: "capz",
+			InitialObjects: []runtime.Object{
+				newAccessTierClusterRoleBinding("removed", "view", defaultnamespacetest.NewGroupSubjects("removed")),
+			},
+			GSAdminGroups: []accessgroup.AccessGroup{{Name: "giantswarm"}},
+			AccessTiers: []accessgroup.AccessTier{
+				{
+					Name:         "platform",
+					Groups:       []accessgroup.AccessGroup{{Name: "platform1"}, {Name: "platform2"}},
+					ClusterRoles: []string{pkgkey.WriteFluxResourcesPermissionsName, pkgkey.WriteSilencesPermissionsName},
+				},
+			},
+			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteAllGSGroupClusterRoleBindingName(),
+					defaultnamespacetest.NewGroupSubjects("giantswarm"),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					"access-tier-platform-write-flux-resources",
+					defaultnamespacetest.NewGroupSubjects("platform1", "platform2"),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					"access-tier-platform-write-silences",
+					defaultnamespacetest.NewGroupSubjects("platform1", "platform2"),
+				),
+			},
+		},
+		{
+			Name:     "case 5: Bind User and ServiceAccount subjects",
+			Provider: "capz",
+			CustomerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customers"},
+				{Name: "jane@acme.com", Kind: rbacv1.UserKind},
+				{Name: "deployer", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"},
+			},
+			GSAdminGroups: []accessgroup.AccessGroup{{Name: "john@giantswarm.io", Kind: rbacv1.UserKind}},
+			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteOrganizationsCustomerGroupClusterRoleBindingName(),
+					newCustomerSubjects(),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.ReadAllCustomerGroupClusterRoleBindingName(),
+					newCustomerSubjects(),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteAllCustomerGroupClusterRoleBindingName(),
+					newCustomerSubjects(),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteAllGSGroupClusterRoleBindingName(),
+					[]rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "john@giantswarm.io"}},
+				),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -185,11 +226,14 @@ func Test_UserGroups(t *testing.T) {
 			}
 
 			userGroups, err := New(Config{
-				K8sClient:           k8sClientFake,
-				Logger:              microloggertest.New(),
-				CustomerAdminGroups: tc.CustomerAdminGroups,
-				GSAdminGroups:       tc.GSAdminGroups,
-				Provider:            tc.Provider,
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+				AccessGroups: accessgroup.NewStore(accessgroup.AccessGroups{
+					WriteAllCustomerGroups:   tc.CustomerAdminGroups,
+					WriteAllGiantswarmGroups: tc.GSAdminGroups,
+					Tiers:                    tc.AccessTiers,
+				}),
+				Provider: tc.Provider,
 			})
 
 			if err == nil {
@@ -217,3 +261,115 @@ func Test_UserGroups(t *testing.T) {
 		})
 	}
 }
+
+func newAccessTierClusterRoleBinding(tier, clusterRole string, subjects []rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+	clusterRoleBinding := defaultnamespacetest.NewClusterRoleBinding(pkgkey.AccessTierBindingName(tier, clusterRole), subjects)
+	clusterRoleBinding.Labels = map[string]string{
+		label.ManagedBy:     project.Name(),
+		pkglabel.AccessTier: tier,
+	}
+	return clusterRoleBinding
+}
+
+func newManagedRoleBinding(name string, subjects []rbacv1.Subject) *rbacv1.RoleBinding {
+	roleBinding := defaultnamespacetest.NewRoleBinding(name, pkgkey.DefaultNamespaceName, subjects)
+	roleBinding.Labels = map[string]string{
+		label.ManagedBy: project.Name(),
+	}
+	return roleBinding
+}
+
+func newCustomerSubjects() []rbacv1.Subject {
+	return []rbacv1.Subject{
+		{Kind: rbacv1.GroupKind, Name: "customers"},
+		{Kind: rbacv1.UserKind, Name: "jane@acme.com"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: "org-acme"},
+	}
+}
+
+func Test_UserGroups_CustomerGroupsRemoved(t *testing.T) {
+	testCases := []struct {
+		Name                        string
+		CustomerReaderGroups        []accessgroup.AccessGroup
+		ExpectedClusterRoleBindings []*rbacv1.ClusterRoleBinding
+	}{
+		{
+			Name:                 "case 0: Delete the bindings of removed customer admin groups and keep binding the readers",
+			CustomerReaderGroups: []accessgroup.AccessGroup{{Name: "readers"}},
+			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.ReadAllCustomerGroupClusterRoleBindingName(),
+					defaultnamespacetest.NewGroupSubjects("readers"),
+				),
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteAllGSGroupClusterRoleBindingName(),
+					defaultnamespacetest.NewGroupSubjects("giantswarm"),
+				),
+			},
+		},
+		{
+			Name: "case 1: Delete all customer bindings once there are no customer groups",
+			ExpectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
+				defaultnamespacetest.NewClusterRoleBinding(
+					pkgkey.WriteAllGSGroupClusterRoleBindingName(),
+					defaultnamespacetest.NewGroupSubjects("giantswarm"),
+				),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			k8sClientFake := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+				CtrlClient: clientfake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+				K8sClient:  clientgofake.NewSimpleClientset(),
+			})
+
+			accessGroups := accessgroup.NewStore(accessgroup.AccessGroups{
+				WriteAllCustomerGroups:   []accessgroup.AccessGroup{{Name: "customers"}},
+				ReadAllCustomerGroups:    []accessgroup.AccessGroup{{Name: "readers"}},
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm"}},
+			})
+
+			userGroups, err := New(Config{
+				K8sClient:    k8sClientFake,
+				Logger:       microloggertest.New(),
+				AccessGroups: accessGroups,
+				Provider:     "capa",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: pkgkey.DefaultNamespaceName}}
+			err = userGroups.EnsureCreated(ctx, namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the customer admin groups are removed from the reloaded configuration
+			accessGroups.Set(accessgroup.AccessGroups{
+				ReadAllCustomerGroups:    tc.CustomerReaderGroups,
+				WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm"}},
+			})
+			err = userGroups.EnsureCreated(ctx, namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterRoleBindingList, err := k8sClientFake.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to get cluster role bindings: %s", err)
+			}
+			defaultnamespacetest.ClusterRoleBindingsShouldEqual(t, tc.ExpectedClusterRoleBindings, clusterRoleBindingList.Items)
+
+			roleBindingList, err := k8sClientFake.K8sClient().RbacV1().RoleBindings(pkgkey.DefaultNamespaceName).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to get role bindings: %s", err)
+			}
+			defaultnamespacetest.RoleBindingsShouldEqual(t, nil, roleBindingList.Items)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/giantswarm/micrologger"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 )
 
@@ -17,20 +18,18 @@ type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	CustomerAdminGroups  []accessgroup.AccessGroup
-	CustomerReaderGroups []accessgroup.AccessGroup
-	GSAdminGroups        []accessgroup.AccessGroup
-	Provider             string
+	AccessGroups *accessgroup.Store
+	Provider     string
+	Protection   protection.Policy
 }
 
 type Resource struct {
 	k8sClient k8sclient.Interface
 	logger    micrologger.Logger
 
-	customerAdminGroups  []accessgroup.AccessGroup
-	customerReaderGroups []accessgroup.AccessGroup
-	gsAdminGroups        []accessgroup.AccessGroup
-	provider             string
+	accessGroups *accessgroup.Store
+	provider     string
+	protection   protection.Policy
 }
 
 func (r Resource) K8sClient() kubernetes.Interface {
@@ -48,18 +47,20 @@ func New(config Config) (*Resource, error) {
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
-	if !accessgroup.ValidateGroups(config.GSAdminGroups) {
-		return nil, microerror.Maskf(invalidConfigError, "%T.GSAdminGroups must not be empty", config)
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
+	if !accessgroup.ValidateGroups(config.AccessGroups.Get().WriteAllGiantswarmGroups) {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups.WriteAllGiantswarmGroups must not be empty", config)
 	}
 
 	r := &Resource{
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
 
-		customerAdminGroups:  config.CustomerAdminGroups,
-		customerReaderGroups: config.CustomerReaderGroups,
-		gsAdminGroups:        config.GSAdminGroups,
-		provider:             config.Provider,
+		accessGroups: config.AccessGroups,
+		provider:     config.Provider,
+		protection:   config.Protection.OrDefault(),
 	}
 
 	return r, nil
@@ -68,3 +69,7 @@ func New(config Config) (*Resource, error) {
 func (r *Resource) Name() string {
 	return Name
 }
+
+func (r *Resource) accessTiers() []accessgroup.AccessTier {
+	return r.accessGroups.Get().AllTiers(r.provider)
+}
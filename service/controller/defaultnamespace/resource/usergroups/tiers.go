@@ -0,0 +1,104 @@
+package usergroups
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+// Ensures a ClusterRoleBinding between each ClusterRole of an access tier and its
+// groups, and deletes the ClusterRoleBindings of tiers and ClusterRoles no longer
+// configured or without groups. Tiers binding a ClusterRole in a binding of the
+// same name share it.
+func (r *Resource) createAccessTierClusterRoleBindings(ctx context.Context) error {
+	var desired []*rbacv1.ClusterRoleBinding
+	desiredByName := map[string]*rbacv1.ClusterRoleBinding{}
+	groups := map[string][]accessgroup.AccessGroup{}
+	var stale []string
+
+	for _, tier := range r.accessTiers() {
+		for _, clusterRole := range tier.ClusterRoles {
+			name := tier.BindingName(clusterRole)
+			if !accessgroup.ValidateGroups(tier.Groups) {
+				stale = append(stale, name)
+				continue
+			}
+
+			groups[name] = accessgroup.MergeGroups(groups[name], tier.Groups)
+			if clusterRoleBinding, ok := desiredByName[name]; ok {
+				clusterRoleBinding.Subjects = accessgroup.GroupsToSubjects(groups[name])
+				continue
+			}
+
+			clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ClusterRoleBinding",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						label.ManagedBy:     project.Name(),
+						pkglabel.AccessTier: tier.Name,
+					},
+				},
+				Subjects: accessgroup.GroupsToSubjects(tier.Groups),
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     clusterRole,
+				},
+			}
+			desired = append(desired, clusterRoleBinding)
+			desiredByName[name] = clusterRoleBinding
+		}
+	}
+
+	for _, clusterRoleBinding := range desired {
+		err := rbac.CreateOrUpdateClusterRoleBinding(r, ctx, clusterRoleBinding)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	// ClusterRoleBindings of the default tiers may have been created before they
+	// were labelled, so that they are also deleted by name
+	for _, name := range stale {
+		if desiredByName[name] != nil {
+			continue
+		}
+
+		err := rbac.DeleteManagedClusterRoleBinding(r, ctx, name)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	existing, err := r.K8sClient().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{
+		LabelSelector: pkglabel.AccessTier,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, clusterRoleBinding := range existing.Items {
+		if desiredByName[clusterRoleBinding.Name] != nil || clusterRoleBinding.Labels[label.ManagedBy] != project.Name() {
+			continue
+		}
+
+		err = rbac.DeleteClusterRoleBinding(r, ctx, clusterRoleBinding.Name)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
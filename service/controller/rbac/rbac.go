@@ -1,15 +1,23 @@
 package rbac
 
 import (
+	"context"
+
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/v7/pkg/controller"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/organizationtrigger"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+	"github.com/giantswarm/rbac-operator/service/internal/trigger"
 
 	"github.com/giantswarm/rbac-operator/pkg/project"
 )
@@ -18,12 +26,20 @@ type RBACConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	WriteAllCustomerGroups []accessgroup.AccessGroup
-	ReadAllCustomerGroups  []accessgroup.AccessGroup
+	AccessGroups *accessgroup.Store
+	// Informers provide the organizations which trigger their namespaces.
+	Informers  trigger.Informers
+	Protection protection.Policy
 }
 
 type RBAC struct {
 	*controller.Controller
+
+	// OrganizationTrigger requeues the namespace of an organization when the
+	// organization changes.
+	OrganizationTrigger *trigger.Trigger
+
+	k8sClient k8sclient.Interface
 }
 
 func NewRBAC(config RBACConfig) (*RBAC, error) {
@@ -31,7 +47,13 @@ func NewRBAC(config RBACConfig) (*RBAC, error) {
 
 	var resources []resource.Interface
 	{
-		c := rbacResourcesConfig(config)
+		c := rbacResourcesConfig{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+
+			AccessGroups: config.AccessGroups,
+			Protection:   config.Protection,
+		}
 
 		resources, err = newRBACResources(c)
 		if err != nil {
@@ -60,9 +82,68 @@ func NewRBAC(config RBACConfig) (*RBAC, error) {
 		}
 	}
 
+	var organizationTrigger *trigger.Trigger
+	{
+		c := organizationtrigger.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+		}
+
+		organizationResource, err := organizationtrigger.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		// The informer of organizations is shared with the triggers of the
+		// roleBindingTemplates.
+		informer, err := config.Informers.GetInformer(context.Background(), new(security.Organization))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		organizationTrigger, err = trigger.New(trigger.Config{
+			Informer: informer,
+			Logger:   config.Logger,
+
+			Name:     "rbac-organization",
+			OnChange: organizationResource.OnChange,
+		})
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	c := &RBAC{
-		Controller: namespaceAuthController,
+		Controller:          namespaceAuthController,
+		OrganizationTrigger: organizationTrigger,
+
+		k8sClient: config.K8sClient,
 	}
 
 	return c, nil
 }
+
+// Resync requeues all namespaces, e.g. after the access groups changed.
+func (c *RBAC) Resync(ctx context.Context) error {
+	namespaces, err := c.k8sClient.K8sClient().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var objects []client.Object
+	for i := range namespaces.Items {
+		objects = append(objects, &namespaces.Items[i])
+	}
+
+	err = resync.Enqueue(ctx, c.k8sClient.CtrlClient(), objects...)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (c *RBAC) Boot(ctx context.Context) {
+	go c.Controller.Boot(ctx)
+	go c.OrganizationTrigger.Run(ctx)
+}
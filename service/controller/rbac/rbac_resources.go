@@ -8,6 +8,7 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 
 	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/automation"
@@ -19,8 +20,8 @@ type rbacResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	WriteAllCustomerGroups []accessgroup.AccessGroup
-	ReadAllCustomerGroups  []accessgroup.AccessGroup
+	AccessGroups *accessgroup.Store
+	Protection   protection.Policy
 }
 
 func newRBACResources(config rbacResourcesConfig) ([]resource.Interface, error) {
@@ -31,6 +32,8 @@ func newRBACResources(config rbacResourcesConfig) ([]resource.Interface, error)
 		c := externalresources.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Protection: config.Protection,
 		}
 
 		externalResourcesResource, err = externalresources.New(c)
@@ -58,8 +61,8 @@ func newRBACResources(config rbacResourcesConfig) ([]resource.Interface, error)
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			WriteAllCustomerGroups: config.WriteAllCustomerGroups,
-			ReadAllCustomerGroups:  config.ReadAllCustomerGroups,
+			AccessGroups: config.AccessGroups,
+			Protection:   config.Protection,
 		}
 
 		namespaceAuthResource, err = namespaceauth.New(c)
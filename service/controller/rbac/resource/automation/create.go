@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/service/controller/rbac/key"
 )
@@ -26,6 +27,11 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	if pause.IsPaused(&ns) {
+		pause.Skip(r.logger, ctx, "Namespace", "", ns.Name)
+		return nil
+	}
+
 	if !key.HasOrganizationOrCustomerLabel(ns) {
 		return nil
 	}
@@ -162,6 +168,8 @@ func (r *Resource) ensurePatchChartsRole(ctx context.Context) error {
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("role %#q in namespace %s has been created", role.Name, pkgkey.GiantSwarmNamespaceName))
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existing) {
+		pause.Skip(r.logger, ctx, "Role", pkgkey.GiantSwarmNamespaceName, role.Name)
 	} else if !reflect.DeepEqual(role.Rules, existing.Rules) {
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating role %#q in namespace %s", role.Name, pkgkey.GiantSwarmNamespaceName))
 
@@ -216,6 +224,8 @@ func (r *Resource) ensurePatchChartsRoleBinding(ctx context.Context, namespace s
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("rolebinding %#q in namespace %s has been created", roleBinding.Name, pkgkey.GiantSwarmNamespaceName))
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existing) {
+		pause.Skip(r.logger, ctx, "RoleBinding", pkgkey.GiantSwarmNamespaceName, roleBinding.Name)
 	} else if !slices.Contains(existing.Subjects, subject) {
 		existing.Subjects = append(existing.Subjects, subject)
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("adding automation SA of namespace %s to rolebinding %#q", namespace, roleBinding.Name))
@@ -245,6 +255,8 @@ func (r *Resource) createOrUpdateClusterRoleBinding(ctx context.Context, ns core
 
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existingClusterRoleBinding) {
+		pause.Skip(r.logger, ctx, "ClusterRoleBinding", "", clusterRoleBinding.Name)
 	} else if needsUpdateClusterRoleBinding(clusterRoleBinding, existingClusterRoleBinding) {
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating cluster role binding %#q for Automation SA in namespace %s", clusterRoleBinding.Name, ns.Name))
 		_, err := r.k8sClient.RbacV1().ClusterRoleBindings().Update(ctx, clusterRoleBinding, metav1.UpdateOptions{})
@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
 	"github.com/giantswarm/rbac-operator/service/test"
 )
 
@@ -42,6 +43,11 @@ func patchChartsRoleBinding(subjects []rbacv1.Subject) *rbacv1.RoleBinding {
 	}, subjects)
 }
 
+func pausedRoleBinding(roleBinding *rbacv1.RoleBinding) *rbacv1.RoleBinding {
+	roleBinding.Annotations = map[string]string{annotation.Paused: "true"}
+	return roleBinding
+}
+
 func patchChartsRole(rules []rbacv1.PolicyRule) *rbacv1.Role {
 	return &rbacv1.Role{
 		TypeMeta: metav1.TypeMeta{
@@ -107,6 +113,14 @@ func Test_EnsureCreated_PatchCharts(t *testing.T) {
 			},
 			expectedSubjects: []rbacv1.Subject{automationSubject("org-customer")},
 		},
+		{
+			name:         "case 4: do not add the org's automation SA to a paused RoleBinding",
+			orgNamespace: "customer",
+			existingResources: []runtime.Object{
+				pausedRoleBinding(patchChartsRoleBinding([]rbacv1.Subject{automationSubject("org-acme")})),
+			},
+			expectedSubjects: []rbacv1.Subject{automationSubject("org-acme")},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -167,6 +181,20 @@ func Test_EnsureDeleted_PatchCharts(t *testing.T) {
 			orgNamespace:     "customer",
 			expectedSubjects: nil,
 		},
+		{
+			name:         "case 3: keep the org's automation SA in a paused RoleBinding",
+			orgNamespace: "customer",
+			existingResources: []runtime.Object{
+				pausedRoleBinding(patchChartsRoleBinding([]rbacv1.Subject{
+					automationSubject("org-acme"),
+					automationSubject("org-customer"),
+				})),
+			},
+			expectedSubjects: []rbacv1.Subject{
+				automationSubject("org-acme"),
+				automationSubject("org-customer"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 	"github.com/giantswarm/rbac-operator/service/controller/rbac/key"
 )
 
@@ -20,6 +21,11 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	if pause.IsPaused(&ns) {
+		pause.Skip(r.logger, ctx, "Namespace", "", ns.Name)
+		return nil
+	}
+
 	if !key.HasOrganizationOrCustomerLabel(ns) {
 		return nil
 	}
@@ -40,11 +46,13 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	}
 
 	for _, clusterRoleBinding := range clusterRoleBindings {
-		_, err = r.k8sClient.RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding, metav1.GetOptions{})
+		existing, err := r.k8sClient.RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBinding, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
 		} else if err != nil {
 			return microerror.Mask(err)
+		} else if pause.IsPaused(existing) {
+			pause.Skip(r.logger, ctx, "ClusterRoleBinding", "", clusterRoleBinding)
 		} else {
 			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleting %#q clusterrolebinding", clusterRoleBinding))
 
@@ -61,11 +69,13 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	}
 
 	serviceAccountName := pkgkey.AutomationServiceAccountName
-	_, err = r.k8sClient.CoreV1().ServiceAccounts(ns.Name).Get(ctx, serviceAccountName, metav1.GetOptions{})
+	serviceAccount, err := r.k8sClient.CoreV1().ServiceAccounts(ns.Name).Get(ctx, serviceAccountName, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		// pass
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(serviceAccount) {
+		pause.Skip(r.logger, ctx, "ServiceAccount", ns.Name, serviceAccountName)
 	} else {
 		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleting serviceaccount %#q from namespace %s", serviceAccountName, ns.Name))
 
@@ -98,6 +108,8 @@ func (r *Resource) removeAutomationSAFromPatchChartsRoleBinding(ctx context.Cont
 		return nil
 	} else if err != nil {
 		return microerror.Mask(err)
+	} else if pause.IsPaused(existing) {
+		pause.Skip(r.logger, ctx, "RoleBinding", pkgkey.GiantSwarmNamespaceName, pkgkey.PatchChartsPermissionsName)
 	} else if slices.Contains(existing.Subjects, subject) {
 		existing.Subjects = slices.DeleteFunc(existing.Subjects, func(s rbacv1.Subject) bool {
 			return s == subject
@@ -102,7 +102,7 @@ func (r *Resource) ensureRoleBindingToClusterRole(ctx context.Context, subjects
 		},
 	}
 
-	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, roleBinding.Namespace, roleBinding); err != nil {
+	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, roleBinding.Namespace, roleBinding); err != nil {
 		return microerror.Mask(err)
 	}
 
@@ -218,7 +218,7 @@ func (r *Resource) ensureDefaultCatalogsRoleBinding(ctx context.Context, subject
 		},
 	}
 
-	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, roleBinding.Namespace, roleBinding); err != nil {
+	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, roleBinding.Namespace, roleBinding); err != nil {
 		return microerror.Mask(err)
 	}
 
@@ -9,6 +9,8 @@ import (
 	"github.com/giantswarm/micrologger"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 )
 
 const (
@@ -18,11 +20,15 @@ const (
 type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	Protection protection.Policy
 }
 
 type Resource struct {
 	k8sClient kubernetes.Interface
 	logger    micrologger.Logger
+
+	protection protection.Policy
 }
 
 func (r Resource) K8sClient() kubernetes.Interface {
@@ -44,6 +50,8 @@ func New(config Config) (*Resource, error) {
 	r := &Resource{
 		k8sClient: config.K8sClient.K8sClient(),
 		logger:    config.Logger,
+
+		protection: config.Protection.OrDefault(),
 	}
 
 	return r, nil
@@ -58,7 +66,7 @@ func getUniqueSubjectsWithClusterRoleRef(orgRoleBindings *rbacv1.RoleBindingList
 	for _, roleBinding := range orgRoleBindings.Items {
 		if roleBindingReferencesClusterRole(roleBinding, clusterRole) && roleBindingHasSubject(roleBinding) {
 			for _, subject := range roleBinding.Subjects {
-				readAllSubjects[subject.Kind+subject.Name] = subject
+				readAllSubjects[subject.Kind+subject.Namespace+subject.Name] = subject
 			}
 		}
 	}
@@ -74,7 +82,7 @@ func getUniqueSubjects(orgRoleBindings *rbacv1.RoleBindingList) []rbacv1.Subject
 	for _, roleBinding := range orgRoleBindings.Items {
 		if roleBindingHasReference(roleBinding) && roleBindingHasSubject(roleBinding) {
 			for _, subject := range roleBinding.Subjects {
-				uniqueSubjects[subject.Kind+subject.Name] = subject
+				uniqueSubjects[subject.Kind+subject.Namespace+subject.Name] = subject
 			}
 		}
 	}
@@ -3,7 +3,6 @@ package namespaceauth
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	k8smetadata "github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
@@ -11,9 +10,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
-
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/service/controller/rbac/key"
 )
@@ -26,6 +24,11 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	if pause.IsPaused(&ns) {
+		pause.Skip(r.logger, ctx, "Namespace", "", ns.Name)
+		return nil
+	}
+
 	if !key.HasOrganizationOrCustomerLabel(ns) {
 		return nil
 	}
@@ -73,75 +76,15 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		}
 	}
 
-	// Bind the ClusterRole created before to the writeAllCustomerGroup (if set)
-	writeAllCustomerGroupSubjects := accessgroup.GroupsToSubjects(r.writeAllCustomerGroups)
-	if len(writeAllCustomerGroupSubjects) > 0 {
-		roleBindingToCustomerGroupName := pkgkey.WriteAllCustomerGroupRoleBindingName()
-
-		writeAllRoleBindingToCustomerGroup := &rbacv1.RoleBinding{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       "RoleBinding",
-				APIVersion: "rbac.authorization.k8s.io/v1",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name: roleBindingToCustomerGroupName,
-				Labels: map[string]string{
-					k8smetadata.ManagedBy: project.Name(),
-				},
-			},
-			Subjects: writeAllCustomerGroupSubjects,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: "rbac.authorization.k8s.io",
-				Kind:     "ClusterRole",
-				Name:     pkgkey.ClusterAdminClusterRoleName,
-			},
-		}
-
-		roleBinding := writeAllRoleBindingToCustomerGroup
-		existingRoleBinding, err := r.k8sClient.RbacV1().RoleBindings(ns.Name).Get(ctx, roleBinding.Name, metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			if !pkgkey.IsProtectedNamespace(ns.Name) {
-				r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("creating rolebinding %#q in namespace %s", roleBinding.Name, ns.Name))
-
-				_, err := r.k8sClient.RbacV1().RoleBindings(ns.Name).Create(ctx, roleBinding, metav1.CreateOptions{})
-				if apierrors.IsAlreadyExists(err) {
-					// do nothing
-				} else if err != nil {
-					return microerror.Mask(err)
-				}
-
-				r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("rolebinding %#q in namespace %s has been created", roleBinding.Name, ns.Name))
-			}
-		} else if err != nil {
-			return microerror.Mask(err)
-		} else if pkgkey.IsProtectedNamespace(ns.Name) {
-			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleting rolebinding %#q in namespace %s", roleBinding.Name, ns.Name))
-			err := r.k8sClient.RbacV1().RoleBindings(ns.Name).Delete(ctx, roleBinding.Name, metav1.DeleteOptions{})
-			if err != nil {
-				return microerror.Mask(err)
-			}
-			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("rolebinding %#q in namespace %s has been deleted", roleBinding.Name, ns.Name))
-		} else if needsUpdate(roleBinding, existingRoleBinding) {
-			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("updating rolebinding %#q in namespace %s", roleBinding.Name, ns.Name))
-			_, err := r.k8sClient.RbacV1().RoleBindings(ns.Name).Update(ctx, roleBinding, metav1.UpdateOptions{})
-			if err != nil {
-				return microerror.Mask(err)
-			}
-			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("rolebinding %#q in namespace %s has been updated", roleBinding.Name, ns.Name))
-		}
-	}
-
-	return nil
-}
-
-func needsUpdate(desiredRoleBinding, existingRoleBinding *rbacv1.RoleBinding) bool {
-	if len(existingRoleBinding.Subjects) < 1 {
-		return true
+	organizationGroups, err := r.getOrganizationGroups(ctx, ns)
+	if err != nil {
+		return microerror.Mask(err)
 	}
 
-	if !reflect.DeepEqual(existingRoleBinding.RoleRef, desiredRoleBinding.RoleRef) {
-		return true
+	err = r.ensureAccessTierRoleBindings(ctx, ns, organizationGroups)
+	if err != nil {
+		return microerror.Mask(err)
 	}
 
-	return !reflect.DeepEqual(existingRoleBinding.Subjects, desiredRoleBinding.Subjects)
+	return nil
 }
@@ -11,11 +11,14 @@ import (
 	security "github.com/giantswarm/organization-operator/api/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 	"github.com/giantswarm/rbac-operator/service/test"
 
@@ -29,8 +32,21 @@ func Test_EnsureCreated(t *testing.T) {
 		orgNamespace        *v1.Namespace
 		existingResources   []runtime.Object
 		customerAdminGroups []accessgroup.AccessGroup
+		customerReadGroups  []accessgroup.AccessGroup
+		organization        *security.Organization
+		accessTiers         []accessgroup.AccessTier
+		organizationGroups  map[string]accessgroup.OrganizationAccessGroups
+		groupPrefixes       map[string]string
+		policy              protection.Policy
 		expectedClusterRole *rbacv1.ClusterRole
 		expectedRoleBinding *rbacv1.RoleBinding
+		expectNoRoleBinding bool
+
+		expectedReaderRoleBinding *rbacv1.RoleBinding
+		expectNoReaderRoleBinding bool
+
+		expectedTierRoleBindings []*rbacv1.RoleBinding
+		expectNoTierRoleBindings []string
 	}{
 		{
 			name:         "case 0: Create a new role binding in case it does not exist",
@@ -148,6 +164,7 @@ func Test_EnsureCreated(t *testing.T) {
 			customerAdminGroups: []accessgroup.AccessGroup{
 				{Name: "customer:giantswarm:Employees"},
 			},
+			expectNoRoleBinding: true,
 		},
 		{
 			name:         "case 7: Delete rolebinding in protected namespace",
@@ -163,6 +180,301 @@ func Test_EnsureCreated(t *testing.T) {
 			customerAdminGroups: []accessgroup.AccessGroup{
 				{Name: "customer:giantswarm:Employees"},
 			},
+			expectNoRoleBinding: true,
+		},
+		{
+			name:         "case 8: Create rolebinding in namespace protected by a policy allowing groups",
+			orgNamespace: test.NewOrgNamespace("internal"),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			policy: protection.Policy{
+				Namespaces: []protection.Namespace{
+					{Name: "org-internal", AllowedSubjects: []protection.AllowedSubject{{Kind: "Group"}}},
+				},
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-internal", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Employees"},
+			}),
+		},
+		{
+			name:         "case 9: Do not create rolebinding in namespace protected by a policy",
+			orgNamespace: test.NewOrgNamespace("internal"),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			policy: protection.Policy{
+				Namespaces: []protection.Namespace{
+					{Name: "org-internal", AllowedSubjects: []protection.AllowedSubject{{Kind: "ServiceAccount", SameNamespace: true}}},
+				},
+			},
+			expectNoRoleBinding: true,
+		},
+		{
+			name:         "case 10: Bind the groups of the organization alongside the customer groups",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			organization: newOrganization("customer", map[string]string{
+				"rbac.giantswarm.io/admin-groups":  "customer:team-a:Admins",
+				"rbac.giantswarm.io/reader-groups": "customer:team-a:Readers, customer:team-a:Auditors",
+			}),
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Employees"},
+				{Kind: "Group", Name: "customer:team-a:Admins"},
+			}),
+			expectedReaderRoleBinding: test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "read-all",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:team-a:Readers"},
+				{Kind: "Group", Name: "customer:team-a:Auditors"},
+			}),
+		},
+		{
+			name: "case 11: Bind only the groups of the organization set on the namespace",
+			orgNamespace: withAnnotations(test.NewOrgNamespace("customer"), map[string]string{
+				"rbac.giantswarm.io/admin-groups":             "customer:team-b:Admins",
+				"rbac.giantswarm.io/organization-groups-only": "true",
+			}),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			customerReadGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Observers"},
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:team-b:Admins"},
+			}),
+			expectNoReaderRoleBinding: true,
+		},
+		{
+			name:         "case 12: Delete the reader role binding once the organization has no reader groups",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			existingResources: []runtime.Object{
+				withLabels(test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "read-all",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:team-a:Readers"},
+				}), map[string]string{"giantswarm.io/managed-by": "rbac-operator"}),
+			},
+			organization:              newOrganization("customer", nil),
+			expectNoReaderRoleBinding: true,
+		},
+		{
+			name:         "case 13: Bind the customer reader groups in the organization namespace",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			customerReadGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Observers"},
+			},
+			organization: newOrganization("customer", map[string]string{
+				"rbac.giantswarm.io/reader-groups": "customer:team-a:Readers",
+			}),
+			expectedReaderRoleBinding: test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "read-all",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Observers"},
+				{Kind: "Group", Name: "customer:team-a:Readers"},
+			}),
+		},
+		{
+			name:         "case 14: Do not bind the customer reader groups outside of organization namespaces",
+			orgNamespace: test.NewClusterNamespace("abc12", "customer"),
+			customerReadGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Observers"},
+			},
+			expectNoReaderRoleBinding: true,
+		},
+		{
+			name:         "case 15: Bind the organization namespace ClusterRoles of access tiers and remove bindings of removed tiers",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			existingResources: []runtime.Object{
+				withLabels(test.NewRoleBinding("access-tier-removed-view", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "view",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "removed"},
+				}), map[string]string{"giantswarm.io/managed-by": "rbac-operator", "rbac.giantswarm.io/access-tier": "removed"}),
+			},
+			accessTiers: []accessgroup.AccessTier{
+				{
+					Name:                              "platform",
+					Groups:                            []accessgroup.AccessGroup{{Name: "customer:platform"}},
+					OrganizationNamespaceClusterRoles: []string{"write-flux-resources"},
+					ClusterNamespaceClusterRoles:      []string{"write-silences"},
+				},
+			},
+			expectedTierRoleBindings: []*rbacv1.RoleBinding{
+				test.NewRoleBinding("access-tier-platform-write-flux-resources", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "write-flux-resources",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:platform"},
+				}),
+			},
+			expectNoTierRoleBindings: []string{"access-tier-removed-view", "access-tier-platform-write-silences"},
+		},
+		{
+			name:         "case 16: Bind the cluster namespace ClusterRoles of access tiers",
+			orgNamespace: test.NewClusterNamespace("abc12", "customer"),
+			accessTiers: []accessgroup.AccessTier{
+				{
+					Name:                              "platform",
+					Groups:                            []accessgroup.AccessGroup{{Name: "customer:platform"}},
+					OrganizationNamespaceClusterRoles: []string{"write-flux-resources"},
+					ClusterNamespaceClusterRoles:      []string{"write-silences"},
+				},
+			},
+			expectedTierRoleBindings: []*rbacv1.RoleBinding{
+				test.NewRoleBinding("access-tier-platform-write-silences", "abc12", map[string]string{
+					"kind": "ClusterRole",
+					"name": "write-silences",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:platform"},
+				}),
+			},
+			expectNoTierRoleBindings: []string{"access-tier-platform-write-flux-resources"},
+		},
+		{
+			name:         "case 17: Bind the groups declared for the organization",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			accessTiers: []accessgroup.AccessTier{
+				{
+					Name:                              "platform",
+					OrganizationNamespaceClusterRoles: []string{"write-flux-resources"},
+				},
+			},
+			organizationGroups: map[string]accessgroup.OrganizationAccessGroups{
+				"customer": {
+					AdminGroups:  []accessgroup.AccessGroup{{Name: "jane@customer.com", Kind: "User"}},
+					ReaderGroups: []accessgroup.AccessGroup{{Name: "customer:team-a:Readers"}},
+					TierGroups: map[string][]accessgroup.AccessGroup{
+						"platform": {{Name: "customer:platform"}},
+					},
+				},
+				"other": {
+					AdminGroups: []accessgroup.AccessGroup{{Name: "other:Admins"}},
+				},
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Employees"},
+				{Kind: "User", Name: "jane@customer.com"},
+			}),
+			expectedReaderRoleBinding: test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "read-all",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:team-a:Readers"},
+			}),
+			expectedTierRoleBindings: []*rbacv1.RoleBinding{
+				test.NewRoleBinding("access-tier-platform-write-flux-resources", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "write-flux-resources",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:platform"},
+				}),
+			},
+		},
+		{
+			name: "case 18: Do not bind the organization groups lacking the group prefix of their tier",
+			orgNamespace: withAnnotations(test.NewOrgNamespace("customer"), map[string]string{
+				"rbac.giantswarm.io/admin-groups":  "customer:team-b:Admins,team-b-admins",
+				"rbac.giantswarm.io/reader-groups": "team-a-readers",
+			}),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			groupPrefixes: map[string]string{
+				accessgroup.CustomerAdminsTierName:  "customer:",
+				accessgroup.CustomerReadersTierName: "customer:",
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Employees"},
+				{Kind: "Group", Name: "customer:team-b:Admins"},
+			}),
+			expectNoReaderRoleBinding: true,
+		},
+		{
+			name:         "case 19: Bind the customer admin groups declared for the organization in its cluster namespaces",
+			orgNamespace: test.NewClusterNamespace("abc12", "customer"),
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			organizationGroups: map[string]accessgroup.OrganizationAccessGroups{
+				"customer": {
+					AdminGroups:  []accessgroup.AccessGroup{{Name: "jane@customer.com", Kind: "User"}},
+					ReaderGroups: []accessgroup.AccessGroup{{Name: "customer:team-a:Readers"}},
+				},
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "abc12", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:giantswarm:Employees"},
+				{Kind: "User", Name: "jane@customer.com"},
+			}),
+			expectNoReaderRoleBinding: true,
+		},
+		{
+			name:         "case 20: Do not replace subjects in a paused role binding",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			existingResources: []runtime.Object{
+				withPaused(test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "cluster-admin",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:acme:Employees"},
+				})),
+			},
+			customerAdminGroups: []accessgroup.AccessGroup{
+				{Name: "customer:giantswarm:Employees"},
+			},
+			expectedRoleBinding: test.NewRoleBinding("write-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "cluster-admin",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:acme:Employees"},
+			}),
+		},
+		{
+			name:         "case 21: Do not delete a paused reader role binding once the organization has no reader groups",
+			orgNamespace: test.NewOrgNamespace("customer"),
+			existingResources: []runtime.Object{
+				withPaused(withLabels(test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+					"kind": "ClusterRole",
+					"name": "read-all",
+				}, []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:team-a:Readers"},
+				}), map[string]string{"giantswarm.io/managed-by": "rbac-operator"})),
+			},
+			organization: newOrganization("customer", nil),
+			expectedReaderRoleBinding: test.NewRoleBinding("read-all-customer-group", "org-customer", map[string]string{
+				"kind": "ClusterRole",
+				"name": "read-all",
+			}, []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:team-a:Readers"},
+			}),
 		},
 	}
 
@@ -175,6 +487,11 @@ func Test_EnsureCreated(t *testing.T) {
 			}
 			runtimeObjects = append(runtimeObjects, tc.existingResources...)
 
+			var ctrlObjects []runtime.Object
+			if tc.organization != nil {
+				ctrlObjects = append(ctrlObjects, tc.organization)
+			}
+
 			var k8sClientFake *k8sclienttest.Clients
 			{
 				schemeBuilder := runtime.SchemeBuilder{
@@ -189,15 +506,23 @@ func Test_EnsureCreated(t *testing.T) {
 				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
 					CtrlClient: clientfake.NewClientBuilder().
 						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(ctrlObjects...).
 						Build(),
 					K8sClient: clientgofake.NewSimpleClientset(runtimeObjects...),
 				})
 			}
 
 			namespaceAuth, err := New(Config{
-				K8sClient:              k8sClientFake,
-				Logger:                 microloggertest.New(),
-				WriteAllCustomerGroups: tc.customerAdminGroups,
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+				AccessGroups: accessgroup.NewStore(accessgroup.AccessGroups{
+					WriteAllCustomerGroups: tc.customerAdminGroups,
+					ReadAllCustomerGroups:  tc.customerReadGroups,
+					Tiers:                  tc.accessTiers,
+					Organizations:          tc.organizationGroups,
+					GroupPrefixes:          tc.groupPrefixes,
+				}),
+				Protection: tc.policy,
 			})
 
 			if err != nil {
@@ -216,10 +541,63 @@ func Test_EnsureCreated(t *testing.T) {
 			if tc.expectedRoleBinding != nil {
 				checkRoleBinding(t, k8sClientFake, tc.expectedRoleBinding)
 			}
+
+			if tc.expectNoRoleBinding {
+				_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings(tc.orgNamespace.Name).Get(context.TODO(), "write-all-customer-group", metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected rolebinding to be absent, got %v", err)
+				}
+			}
+
+			if tc.expectedReaderRoleBinding != nil {
+				checkRoleBinding(t, k8sClientFake, tc.expectedReaderRoleBinding)
+			}
+
+			if tc.expectNoReaderRoleBinding {
+				_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings(tc.orgNamespace.Name).Get(context.TODO(), "read-all-customer-group", metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected reader rolebinding to be absent, got %v", err)
+				}
+			}
+
+			for _, roleBinding := range tc.expectedTierRoleBindings {
+				checkRoleBinding(t, k8sClientFake, roleBinding)
+			}
+
+			for _, name := range tc.expectNoTierRoleBindings {
+				_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings(tc.orgNamespace.Name).Get(context.TODO(), name, metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected rolebinding %s to be absent, got %v", name, err)
+				}
+			}
 		})
 	}
 }
 
+func newOrganization(name string, annotations map[string]string) *security.Organization {
+	return &security.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: annotations,
+		},
+	}
+}
+
+func withAnnotations(namespace *v1.Namespace, annotations map[string]string) *v1.Namespace {
+	namespace.Annotations = annotations
+	return namespace
+}
+
+func withLabels(roleBinding *rbacv1.RoleBinding, labels map[string]string) *rbacv1.RoleBinding {
+	roleBinding.Labels = labels
+	return roleBinding
+}
+
+func withPaused(roleBinding *rbacv1.RoleBinding) *rbacv1.RoleBinding {
+	roleBinding.Annotations = map[string]string{annotation.Paused: "true"}
+	return roleBinding
+}
+
 func checkClusterRole(t *testing.T, k8sClient k8sclient.Interface, expectedClusterRole *rbacv1.ClusterRole) {
 	clusterRole, err := k8sClient.K8sClient().RbacV1().ClusterRoles().Get(context.TODO(), expectedClusterRole.Name, metav1.GetOptions{})
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 	"github.com/giantswarm/rbac-operator/service/controller/rbac/key"
 )
 
@@ -18,6 +19,11 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	if pause.IsPaused(&ns) {
+		pause.Skip(r.logger, ctx, "Namespace", "", ns.Name)
+		return nil
+	}
+
 	if !key.HasOrganizationOrCustomerLabel(ns) {
 		return nil
 	}
@@ -27,11 +33,13 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	}
 
 	for _, clusterRole := range clusterRoles {
-		_, err = r.k8sClient.RbacV1().ClusterRoles().Get(ctx, clusterRole, metav1.GetOptions{})
+		existing, err := r.k8sClient.RbacV1().ClusterRoles().Get(ctx, clusterRole, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
 		} else if err != nil {
 			return microerror.Mask(err)
+		} else if pause.IsPaused(existing) {
+			pause.Skip(r.logger, ctx, "ClusterRole", "", clusterRole)
 		} else {
 			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleting clusterrole %#q", clusterRole))
 
@@ -49,15 +57,18 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 
 	roleBindings := []string{
 		pkgkey.WriteAllCustomerGroupRoleBindingName(),
+		pkgkey.ReadAllCustomerGroupClusterRoleBindingName(),
 		pkgkey.WriteAllAutomationSARoleBindingName(),
 	}
 
 	for _, roleBinding := range roleBindings {
-		_, err = r.k8sClient.RbacV1().RoleBindings(ns.Name).Get(ctx, roleBinding, metav1.GetOptions{})
+		existing, err := r.k8sClient.RbacV1().RoleBindings(ns.Name).Get(ctx, roleBinding, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
 		} else if err != nil {
 			return microerror.Mask(err)
+		} else if pause.IsPaused(existing) {
+			pause.Skip(r.logger, ctx, "RoleBinding", ns.Name, roleBinding)
 		} else {
 			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleting rolebinding %#q from namespace %s", roleBinding, ns.Name))
 
@@ -0,0 +1,84 @@
+package namespaceauth
+
+import (
+	"context"
+	"fmt"
+
+	k8smetadata "github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+// organizationGroups are the groups granted access tiers in a single organization
+// using annotations on the Organization CR or its namespace, or AccessGroup resources.
+type organizationGroups struct {
+	// annotated are the groups from the annotations, by tier name
+	annotated map[string][]accessgroup.AccessGroup
+	declared  accessgroup.OrganizationAccessGroups
+	// only is true if the global customer groups are not bound in the organization namespace
+	only bool
+}
+
+// groups returns the groups granted the tier in the organization.
+func (g organizationGroups) groups(tier string) []accessgroup.AccessGroup {
+	return accessgroup.MergeGroups(g.annotated[tier], g.declared.Groups(tier))
+}
+
+// getOrganizationGroups returns the groups declared for the organization the namespace
+// belongs to and, in organization namespaces, the groups from the annotations of the
+// organization and of the namespace itself.
+func (r *Resource) getOrganizationGroups(ctx context.Context, ns corev1.Namespace) (organizationGroups, error) {
+	accessGroups := r.accessGroups.Get()
+
+	var groups organizationGroups
+	if !pkgkey.IsOrgNamespace(ns.Name) {
+		if organization := ns.Labels[k8smetadata.Organization]; organization != "" {
+			groups.declared = accessGroups.Organizations[organization]
+		}
+		return groups, nil
+	}
+
+	annotations := []map[string]string{ns.Annotations}
+	{
+		organization := &security.Organization{}
+		err := r.ctrlClient.Get(ctx, client.ObjectKey{Name: pkgkey.OrganizationName(ns.Name)}, organization)
+		if apierrors.IsNotFound(err) {
+			// only the namespace annotations are used
+		} else if err != nil {
+			return organizationGroups{}, microerror.Mask(err)
+		} else {
+			annotations = append(annotations, organization.Annotations)
+		}
+	}
+
+	var adminGroups, readerGroups []accessgroup.AccessGroup
+	for _, a := range annotations {
+		adminGroups = accessgroup.MergeGroups(adminGroups, accessgroup.ParseGroups(a[annotation.OrganizationAdminGroups]))
+		readerGroups = accessgroup.MergeGroups(readerGroups, accessgroup.ParseGroups(a[annotation.OrganizationReaderGroups]))
+		if a[annotation.OrganizationGroupsOnly] == "true" {
+			groups.only = true
+		}
+	}
+
+	var rejectedAdmins, rejectedReaders []accessgroup.RejectedGroup
+	adminGroups, rejectedAdmins = accessGroups.NormalizeGroups(accessgroup.CustomerAdminsTierName, adminGroups)
+	readerGroups, rejectedReaders = accessGroups.NormalizeGroups(accessgroup.CustomerReadersTierName, readerGroups)
+	for _, group := range append(rejectedAdmins, rejectedReaders...) {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("group %#q of tier %#q annotated for namespace %s lacks the group prefix %#q and is not bound", group.Name, group.Tier, ns.Name, group.Prefix))
+	}
+
+	groups.annotated = map[string][]accessgroup.AccessGroup{
+		accessgroup.CustomerAdminsTierName:  adminGroups,
+		accessgroup.CustomerReadersTierName: readerGroups,
+	}
+	groups.declared = accessGroups.Organizations[pkgkey.OrganizationName(ns.Name)]
+
+	return groups, nil
+}
@@ -5,9 +5,11 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
 
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -18,16 +20,17 @@ type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
 
-	WriteAllCustomerGroups []accessgroup.AccessGroup
-	ReadAllCustomerGroups  []accessgroup.AccessGroup
+	AccessGroups *accessgroup.Store
+	Protection   protection.Policy
 }
 
 type Resource struct {
-	k8sClient kubernetes.Interface
-	logger    micrologger.Logger
+	ctrlClient client.Client
+	k8sClient  kubernetes.Interface
+	logger     micrologger.Logger
 
-	writeAllCustomerGroups []accessgroup.AccessGroup
-	readAllCustomerGroups  []accessgroup.AccessGroup
+	accessGroups *accessgroup.Store
+	protection   protection.Policy
 }
 
 func New(config Config) (*Resource, error) {
@@ -37,18 +40,36 @@ func New(config Config) (*Resource, error) {
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
+	if config.AccessGroups == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.AccessGroups must not be empty", config)
+	}
 
 	r := &Resource{
-		k8sClient: config.K8sClient.K8sClient(),
-		logger:    config.Logger,
+		ctrlClient: config.K8sClient.CtrlClient(),
+		k8sClient:  config.K8sClient.K8sClient(),
+		logger:     config.Logger,
 
-		writeAllCustomerGroups: config.WriteAllCustomerGroups,
-		readAllCustomerGroups:  config.ReadAllCustomerGroups,
+		accessGroups: config.AccessGroups,
+		protection:   config.Protection.OrDefault(),
 	}
 
 	return r, nil
 }
 
+func (r *Resource) K8sClient() kubernetes.Interface {
+	return r.k8sClient
+}
+
+func (r *Resource) Logger() micrologger.Logger {
+	return r.logger
+}
+
 func (r *Resource) Name() string {
 	return Name
 }
+
+// accessTiers returns the default and configured tiers. The provider only adds
+// ClusterRoles bound cluster-wide, which are not bound in namespaces.
+func (r *Resource) accessTiers() []accessgroup.AccessTier {
+	return r.accessGroups.Get().AllTiers("")
+}
@@ -0,0 +1,119 @@
+package namespaceauth
+
+import (
+	"context"
+
+	k8smetadata "github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+// ensureAccessTierRoleBindings binds the organization namespace ClusterRoles, or in other
+// namespaces of the organization, e.g. its cluster namespaces, the cluster namespace ClusterRoles
+// of each access tier to its groups and the groups granted the tier in the organization, and
+// deletes the RoleBindings of tiers and ClusterRoles no longer configured or without groups.
+// Tiers binding a ClusterRole in a RoleBinding of the same name share it.
+func (r *Resource) ensureAccessTierRoleBindings(ctx context.Context, ns corev1.Namespace, organizationGroups organizationGroups) error {
+	var desired []*rbacv1.RoleBinding
+	desiredByName := map[string]*rbacv1.RoleBinding{}
+	groups := map[string][]accessgroup.AccessGroup{}
+	var stale []string
+
+	for _, tier := range r.accessTiers() {
+		clusterRoles := tier.ClusterNamespaceClusterRoles
+		if pkgkey.IsOrgNamespace(ns.Name) {
+			clusterRoles = tier.OrganizationNamespaceClusterRoles
+		}
+
+		tierGroups := tier.Groups
+		if organizationGroups.only && (tier.Name == accessgroup.CustomerAdminsTierName || tier.Name == accessgroup.CustomerReadersTierName) {
+			tierGroups = nil
+		}
+		tierGroups = accessgroup.MergeGroups(tierGroups, organizationGroups.groups(tier.Name))
+
+		for _, clusterRole := range clusterRoles {
+			name := tier.BindingName(clusterRole)
+			if !accessgroup.ValidateGroups(tierGroups) {
+				stale = append(stale, name)
+				continue
+			}
+
+			groups[name] = accessgroup.MergeGroups(groups[name], tierGroups)
+			if roleBinding, ok := desiredByName[name]; ok {
+				roleBinding.Subjects = accessgroup.GroupsToSubjects(groups[name])
+				continue
+			}
+
+			roleBinding := &rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "RoleBinding",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns.Name,
+					Labels: map[string]string{
+						k8smetadata.ManagedBy: project.Name(),
+						pkglabel.AccessTier:   tier.Name,
+					},
+				},
+				Subjects: accessgroup.GroupsToSubjects(groups[name]),
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     clusterRole,
+				},
+			}
+			desired = append(desired, roleBinding)
+			desiredByName[name] = roleBinding
+		}
+	}
+
+	for _, roleBinding := range desired {
+		err := rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, ns.Name, roleBinding)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	// RoleBindings of the default tiers may have been created before they were
+	// labelled, so that they are also deleted by name
+	for _, name := range stale {
+		if desiredByName[name] != nil {
+			continue
+		}
+
+		err := rbac.DeleteManagedRoleBinding(r, ctx, ns.Name, name)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	existing, err := r.k8sClient.RbacV1().RoleBindings(ns.Name).List(ctx, metav1.ListOptions{
+		LabelSelector: pkglabel.AccessTier,
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, roleBinding := range existing.Items {
+		if desiredByName[roleBinding.Name] != nil || roleBinding.Labels[k8smetadata.ManagedBy] != project.Name() {
+			continue
+		}
+
+		err = rbac.DeleteRoleBinding(r, ctx, ns.Name, roleBinding.Name)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package organizationtrigger
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+)
+
+// OnChange requeues the namespace of the given organization. Nothing is done
+// for deleted organizations, their namespace is deleted together with them.
+func (r *Resource) OnChange(ctx context.Context, obj client.Object) error {
+	organization, ok := obj.(*security.Organization)
+	if !ok {
+		return microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &security.Organization{}, obj)
+	}
+
+	if organization.Status.Namespace == "" || organization.DeletionTimestamp != nil {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	namespace.Name = organization.Status.Namespace
+
+	r.logger.Debugf(ctx, "Requeueing namespace %s of organization %s", organization.Status.Namespace, organization.Name)
+	err := resync.Enqueue(ctx, r.k8sClient.CtrlClient(), namespace)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package rolebinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+// getAdoptionOutcome applies the adoption policy of the template to an existing role binding with the
+// same name as the given one. No outcome is returned if there is none or it is managed by rbac-operator.
+func (r *Resource) getAdoptionOutcome(ctx context.Context, template v1alpha1.RoleBindingTemplate, roleBinding *rbacv1.RoleBinding) (v1alpha1.AdoptionOutcome, error) {
+	existing, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(roleBinding.Namespace).Get(ctx, roleBinding.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", microerror.Mask(err)
+	}
+	if isManaged(existing) {
+		return "", nil
+	}
+
+	switch template.Spec.AdoptionPolicy {
+	case v1alpha1.AdoptionPolicyAdopt:
+		r.logger.Debugf(ctx, "Adopting roleBinding %s in namespace %s", roleBinding.Name, roleBinding.Namespace)
+		return v1alpha1.AdoptionOutcomeAdopted, nil
+	case v1alpha1.AdoptionPolicySkip:
+		r.logger.Debugf(ctx, "Skipping roleBinding %s in namespace %s not managed by %s", roleBinding.Name, roleBinding.Namespace, project.Name())
+		return v1alpha1.AdoptionOutcomeSkipped, nil
+	default:
+		return v1alpha1.AdoptionOutcomeFailed, nil
+	}
+}
+
+// isManaged reports whether the role binding was created by rbac-operator
+func isManaged(roleBinding *rbacv1.RoleBinding) bool {
+	return roleBinding.Labels[label.ManagedBy] == project.Name()
+}
+
+func adoptionFailedMessage(roleBinding *rbacv1.RoleBinding) string {
+	return fmt.Sprintf("RoleBinding %s already exists and is not managed by %s", roleBinding.Name, project.Name())
+}
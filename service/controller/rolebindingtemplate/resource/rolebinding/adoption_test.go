@@ -0,0 +1,192 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+func TestEnsureCreatedAdoption(t *testing.T) {
+	customerSubjects := []rbacv1.Subject{{Kind: "Group", Name: "customer-group"}}
+	templateSubjects := []rbacv1.Subject{{Kind: "Group", Name: "test-group"}}
+
+	testCases := []struct {
+		Name             string
+		AdoptionPolicy   v1alpha1.AdoptionPolicy
+		ManagedBy        string
+		ExistingSubjects []rbacv1.Subject
+
+		expectedSubjects   []rbacv1.Subject
+		expectedManagedBy  string
+		expectedNamespaces []string
+		expectedUnmanaged  []v1alpha1.UnmanagedRoleBinding
+		expectedFailed     []v1alpha1.FailedNamespace
+	}{
+		{
+			Name:               "case0: adopt existing role binding",
+			AdoptionPolicy:     v1alpha1.AdoptionPolicyAdopt,
+			expectedSubjects:   templateSubjects,
+			expectedManagedBy:  project.Name(),
+			expectedNamespaces: []string{"org-example"},
+			expectedUnmanaged: []v1alpha1.UnmanagedRoleBinding{
+				{Namespace: "org-example", Name: "something", Outcome: v1alpha1.AdoptionOutcomeAdopted},
+			},
+		},
+		{
+			Name:             "case1: skip existing role binding",
+			AdoptionPolicy:   v1alpha1.AdoptionPolicySkip,
+			expectedSubjects: customerSubjects,
+			expectedUnmanaged: []v1alpha1.UnmanagedRoleBinding{
+				{Namespace: "org-example", Name: "something", Outcome: v1alpha1.AdoptionOutcomeSkipped},
+			},
+		},
+		{
+			Name:             "case2: fail on existing role binding by default",
+			expectedSubjects: customerSubjects,
+			expectedUnmanaged: []v1alpha1.UnmanagedRoleBinding{
+				{Namespace: "org-example", Name: "something", Outcome: v1alpha1.AdoptionOutcomeFailed},
+			},
+			expectedFailed: []v1alpha1.FailedNamespace{
+				{Namespace: "org-example", Reason: "AdoptionFailed", Message: "RoleBinding something already exists and is not managed by rbac-operator"},
+			},
+		},
+		{
+			Name:               "case3: update role binding managed by rbac-operator",
+			AdoptionPolicy:     v1alpha1.AdoptionPolicyFail,
+			ManagedBy:          project.Name(),
+			expectedSubjects:   templateSubjects,
+			expectedManagedBy:  project.Name(),
+			expectedNamespaces: []string{"org-example"},
+		},
+		{
+			Name:               "case4: adopt existing role binding with identical subjects",
+			AdoptionPolicy:     v1alpha1.AdoptionPolicyAdopt,
+			ExistingSubjects:   templateSubjects,
+			expectedSubjects:   templateSubjects,
+			expectedManagedBy:  project.Name(),
+			expectedNamespaces: []string{"org-example"},
+			expectedUnmanaged: []v1alpha1.UnmanagedRoleBinding{
+				{Namespace: "org-example", Name: "something", Outcome: v1alpha1.AdoptionOutcomeAdopted},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "something",
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef:  rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+						Subjects: templateSubjects,
+					},
+					AdoptionPolicy: tc.AdoptionPolicy,
+				},
+				Status: v1alpha1.RoleBindingTemplateStatus{
+					// the role binding must not be removed even though the namespace is no longer applied to
+					Namespaces: []string{"org-example"},
+				},
+			}
+
+			existing := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "something",
+					Namespace: "org-example",
+					Labels:    map[string]string{},
+					Annotations: map[string]string{
+						annotation.Notes: "Generated based on RoleBindingTemplate something",
+					},
+				},
+				RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Name: "example", Kind: "ClusterRole"},
+				Subjects: customerSubjects,
+			}
+			if tc.ExistingSubjects != nil {
+				existing.Subjects = tc.ExistingSubjects
+			}
+			if tc.ManagedBy != "" {
+				existing.Labels[label.ManagedBy] = tc.ManagedBy
+			}
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(template, getTestOrganization("example")).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+						existing,
+					),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, template)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			roleBinding, err := k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "something", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected role binding, got error %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedSubjects, roleBinding.Subjects) {
+				t.Fatalf("Expected subjects %v, got %v", tc.expectedSubjects, roleBinding.Subjects)
+			}
+			if roleBinding.Labels[label.ManagedBy] != tc.expectedManagedBy {
+				t.Fatalf("Expected managed by %q, got %q", tc.expectedManagedBy, roleBinding.Labels[label.ManagedBy])
+			}
+
+			result := &v1alpha1.RoleBindingTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expectedNamespaces, result.Status.Namespaces) {
+				t.Fatalf("Expected namespaces %v, got %v", tc.expectedNamespaces, result.Status.Namespaces)
+			}
+			if !reflect.DeepEqual(tc.expectedUnmanaged, result.Status.UnmanagedRoleBindings) {
+				t.Fatalf("Expected unmanaged role bindings %v, got %v", tc.expectedUnmanaged, result.Status.UnmanagedRoleBindings)
+			}
+			if !reflect.DeepEqual(tc.expectedFailed, result.Status.FailedNamespaces) {
+				t.Fatalf("Expected failed namespaces %v, got %v", tc.expectedFailed, result.Status.FailedNamespaces)
+			}
+		})
+	}
+}
@@ -3,17 +3,25 @@ package rolebinding
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/giantswarm/k8smetadata/pkg/annotation"
 	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/giantswarm/rbac-operator/api/v1alpha1"
-	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	pkgannotation "github.com/giantswarm/rbac-operator/pkg/annotation"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
 	"github.com/giantswarm/rbac-operator/pkg/project"
-	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/key"
 )
 
@@ -23,38 +31,153 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	// a paused template leaves its role bindings alone until the annotation is removed
+	if pause.IsPaused(&template) {
+		pause.Skip(r.logger, ctx, "RoleBindingTemplate", "", template.Name)
+		setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "Paused", fmt.Sprintf("Paused by the %s annotation", pkgannotation.Paused))
+		r.updateStatus(ctx, &template)
+		return nil
+	}
+
 	namespaces, err := r.getNamespacesFromScope(ctx, template.Spec.Scopes)
 	if err != nil {
+		setCondition(&template, v1alpha1.ConditionTypeScopeResolved, metav1.ConditionFalse, "ScopeResolutionFailed", err.Error())
+		setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ScopeResolutionFailed", "Namespaces in scope could not be determined")
+		r.updateStatus(ctx, &template)
 		return microerror.Mask(err)
 	}
+	setCondition(&template, v1alpha1.ConditionTypeScopeResolved, metav1.ConditionTrue, "ScopeResolved", fmt.Sprintf("%d namespaces in scope", len(namespaces)))
+
+	// outside of the validity window no role bindings are applied, existing ones are pruned below
+	validity := getValidity(template.Spec, time.Now())
+	setActiveCondition(&template, validity)
+	if validity.next != nil {
+		r.requeueAt(template, *validity.next)
+	} else {
+		r.cancelRequeue(template)
+	}
+	if !validity.active {
+		namespaces = nil
+	}
+
+	sourced, err := r.resolveSubjectsFrom(ctx, template)
+	if err != nil {
+		setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "SubjectsFromFailed", err.Error())
+		r.updateStatus(ctx, &template)
+		return microerror.Mask(err)
+	}
+
+	if template.Spec.Mode == v1alpha1.RoleBindingTemplateModePlan {
+		return r.plan(ctx, template, namespaces, sourced)
+	}
 
 	status := []string{}
-	for _, ns := range namespaces {
-		roleBinding, err := getRoleBindingFromTemplate(template, ns)
+	var failed []v1alpha1.FailedNamespace
+	var paused []string
+	var unmanaged []v1alpha1.UnmanagedRoleBinding
+	owned := map[types.NamespacedName]bool{}
+	for _, namespace := range namespaces {
+		ns := namespace.Name
+		roleBinding, err := getRoleBindingFromTemplate(template, namespace)
 		if err != nil {
+			setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "InvalidTemplate", err.Error())
+			r.updateStatus(ctx, &template)
+			return microerror.Mask(err)
+		}
+
+		// role bindings in paused namespaces are neither applied nor deleted
+		if pause.IsPaused(&namespace) {
+			pause.Skip(r.logger, ctx, "RoleBinding", ns, roleBinding.Name)
+			owned[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+			paused = append(paused, ns)
+			continue
+		}
+		if err = r.addSourcedSubjects(ctx, sourced, namespace, roleBinding); err != nil {
 			return microerror.Mask(err)
 		}
 
-		roleBinding = cleanSubjects(roleBinding, ns)
+		roleBinding = cleanSubjects(r.protection, roleBinding, ns)
 		if len(roleBinding.Subjects) > 0 {
-			if err = rbac.CreateOrUpdateRoleBinding(r, ctx, ns, roleBinding); err != nil {
+			// role bindings which were not created by rbac-operator are only touched if the adoption policy allows it
+			outcome, err := r.getAdoptionOutcome(ctx, template, roleBinding)
+			if err != nil {
+				r.logger.Debugf(ctx, "Could not check roleBinding %s in namespace %s due to error %v", roleBinding.Name, ns, err)
+				// the role binding may still grant access, it is kept until it can be checked again
+				owned[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+				failed = append(failed, v1alpha1.FailedNamespace{
+					Namespace: ns,
+					Reason:    failureReason(err),
+					Message:   err.Error(),
+				})
+				continue
+			}
+			if outcome != "" {
+				unmanaged = append(unmanaged, v1alpha1.UnmanagedRoleBinding{
+					Namespace: ns,
+					Name:      roleBinding.Name,
+					Outcome:   outcome,
+				})
+			}
+			if outcome == v1alpha1.AdoptionOutcomeSkipped {
+				continue
+			}
+			if outcome == v1alpha1.AdoptionOutcomeFailed {
+				failed = append(failed, v1alpha1.FailedNamespace{
+					Namespace: ns,
+					Reason:    "AdoptionFailed",
+					Message:   adoptionFailedMessage(roleBinding),
+				})
+				continue
+			}
+
+			// role bindings rendered by other templates are only touched when merging subjects
+			owned[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+			if err = r.applyRoleBinding(ctx, template, roleBinding); err != nil {
 				r.logger.Debugf(ctx, "Could not apply roleBinding %s to namespace %s due to error %v", roleBinding.Name, ns, err)
+				failed = append(failed, v1alpha1.FailedNamespace{
+					Namespace: ns,
+					Reason:    failureReason(err),
+					Message:   err.Error(),
+				})
 				continue
 			}
 			status = append(status, ns)
 		}
 	}
 
-	// go through old list of namespaces and compare for scope changes
+	// go through old list of namespaces and compare for scope changes, role bindings which failed
+	// to be applied are kept, as they may still grant the access they granted before
 	for _, ns := range template.Status.Namespaces {
-		if !contains(status, ns) {
-			if err = rbac.DeleteRoleBinding(r, ctx, ns, getRoleBindingNameFromTemplate(template)); err != nil {
+		if !contains(status, ns) && !contains(paused, ns) && !containsFailed(failed, ns) {
+			if err = r.deleteRoleBinding(ctx, template, ns); err != nil {
 				return microerror.Mask(err)
 			}
 		}
 	}
 
+	// role bindings missing from the old list, e.g. due to a failed status update, are found by their labels
+	if err = r.deleteOwnedRoleBindings(ctx, template, owned); err != nil {
+		return microerror.Mask(err)
+	}
+
 	template.Status.Namespaces = status
+	template.Status.FailedNamespaces = failed
+	template.Status.PausedNamespaces = paused
+	template.Status.UnmanagedRoleBindings = unmanaged
+	template.Status.Plan = nil
+	if len(failed) > 0 {
+		message := fmt.Sprintf("RoleBinding could not be applied to %d of %d namespaces", len(failed), len(namespaces))
+		setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ApplyFailed", message)
+		setCondition(&template, v1alpha1.ConditionTypeDegraded, metav1.ConditionTrue, "ApplyFailed", message)
+	} else {
+		message := fmt.Sprintf("RoleBinding applied to %d namespaces", len(status))
+		if len(paused) > 0 {
+			message = fmt.Sprintf("%s, %d namespaces paused", message, len(paused))
+		}
+		setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionTrue, "Applied", message)
+		setCondition(&template, v1alpha1.ConditionTypeDegraded, metav1.ConditionFalse, "Applied", message)
+	}
+	template.Status.ObservedGeneration = template.Generation
 	if err := r.k8sClient.CtrlClient().Status().Update(ctx, &template); err != nil {
 		return microerror.Mask(err)
 	}
@@ -62,22 +185,41 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
-func getRoleBindingFromTemplate(template v1alpha1.RoleBindingTemplate, namespace string) (*rbacv1.RoleBinding, error) {
+func getRoleBindingFromTemplate(template v1alpha1.RoleBindingTemplate, namespace corev1.Namespace) (*rbacv1.RoleBinding, error) {
+	variables := getTemplateVariables(namespace)
+
+	roleBindingName, err := getRoleBindingNameForNamespace(template, namespace)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	objectMeta := template.Spec.Template.ObjectMeta
 	{
 		// ensure namespaced name
-		objectMeta.Name = getRoleBindingNameFromTemplate(template)
-		objectMeta.Namespace = namespace
+		objectMeta.Name = roleBindingName
+		objectMeta.Namespace = namespace.Name
 		// add labels and annotations
-		labels := objectMeta.GetLabels()
-		if labels == nil {
-			labels = map[string]string{}
+		labels := map[string]string{}
+		for k, v := range objectMeta.GetLabels() {
+			labels[k], err = renderValue(v, variables)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
 		}
 		labels[label.ManagedBy] = project.Name()
+		if len(validation.IsValidLabelValue(template.Name)) == 0 {
+			labels[pkglabel.RoleBindingTemplate] = template.Name
+		}
+		if template.UID != "" {
+			labels[pkglabel.RoleBindingTemplateUID] = string(template.UID)
+		}
 		objectMeta.SetLabels(labels)
-		annotations := objectMeta.GetAnnotations()
-		if annotations == nil {
-			annotations = map[string]string{}
+		annotations := map[string]string{}
+		for k, v := range objectMeta.GetAnnotations() {
+			annotations[k], err = renderValue(v, variables)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
 		}
 		if annotations[annotation.Notes] == "" {
 			annotations[annotation.Notes] = fmt.Sprintf("Generated based on RoleBindingTemplate %s", template.Name)
@@ -100,8 +242,12 @@ func getRoleBindingFromTemplate(template v1alpha1.RoleBindingTemplate, namespace
 	var subjects []rbacv1.Subject
 	{
 		for _, subject := range template.Spec.Template.Subjects {
+			subject.Name, err = renderValue(subject.Name, variables)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
 			if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
-				subject.Namespace = namespace
+				subject.Namespace = namespace.Name
 			}
 			subjects = append(subjects, subject)
 		}
@@ -119,22 +265,9 @@ func getRoleBindingFromTemplate(template v1alpha1.RoleBindingTemplate, namespace
 	}, nil
 }
 
-func cleanSubjects(roleBinding *rbacv1.RoleBinding, namespace string) *rbacv1.RoleBinding {
-	// if the rolebinding is in a protected namespace, subjects can only be serviceAccounts in flux namespace or the same namespace
-	if !pkgkey.IsProtectedNamespace(namespace) {
-		return roleBinding
-	}
-	var validSubjects []rbacv1.Subject
-	for _, subject := range roleBinding.Subjects {
-		if subject.Kind != rbacv1.ServiceAccountKind {
-			continue
-		}
-		if subject.Namespace != pkgkey.FluxNamespaceName && subject.Namespace != namespace {
-			continue
-		}
-		validSubjects = append(validSubjects, subject)
-	}
-	roleBinding.Subjects = validSubjects
+// cleanSubjects removes the subjects which may not be bound in the namespace according to the protection policy
+func cleanSubjects(policy protection.Policy, roleBinding *rbacv1.RoleBinding, namespace string) *rbacv1.RoleBinding {
+	roleBinding.Subjects = policy.FilterSubjects(namespace, roleBinding.Subjects)
 	return roleBinding
 }
 
@@ -156,3 +289,42 @@ func contains(s []string, e string) bool {
 	}
 	return false
 }
+
+func containsFailed(failed []v1alpha1.FailedNamespace, ns string) bool {
+	for _, f := range failed {
+		if f.Namespace == ns {
+			return true
+		}
+	}
+	return false
+}
+
+func setCondition(template *v1alpha1.RoleBindingTemplate, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&template.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: template.Generation,
+	})
+}
+
+// updateStatus writes the status of a template whose reconciliation is failing,
+// errors are only logged so the original error is returned to the controller.
+func (r *Resource) updateStatus(ctx context.Context, template *v1alpha1.RoleBindingTemplate) {
+	template.Status.ObservedGeneration = template.Generation
+	if err := r.k8sClient.CtrlClient().Status().Update(ctx, template); err != nil {
+		r.logger.Debugf(ctx, "Could not update status of RoleBindingTemplate %s due to error %v", template.Name, err)
+	}
+}
+
+// failureReason returns the API reason of the error if known, e.g. Forbidden or Invalid.
+func failureReason(err error) string {
+	if IsCollision(err) {
+		return "Collision"
+	}
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return "ApplyFailed"
+}
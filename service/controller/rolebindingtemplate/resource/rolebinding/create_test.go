@@ -2,6 +2,7 @@ package rolebinding
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -12,16 +13,22 @@ import (
 	security "github.com/giantswarm/organization-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	clientgotesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/giantswarm/rbac-operator/api/v1alpha1"
 	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
 	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/defaultnamespacetest"
+	"github.com/giantswarm/rbac-operator/service/test"
 )
 
 func TestGetRoleBindingFromTemplate(t *testing.T) {
@@ -127,8 +134,9 @@ func TestGetRoleBindingFromTemplate(t *testing.T) {
 						Name:      "name",
 						Namespace: "another-namespace",
 						Labels: map[string]string{
-							"the-label":     "the-value",
-							label.ManagedBy: project.Name(),
+							"the-label":                  "the-value",
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "another-name",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "There is already a note here",
@@ -185,8 +193,9 @@ func TestGetRoleBindingFromTemplate(t *testing.T) {
 						Name:      "name",
 						Namespace: "another-namespace-1",
 						Labels: map[string]string{
-							"the-label":     "the-value",
-							label.ManagedBy: project.Name(),
+							"the-label":                  "the-value",
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "another-name",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "There is already a note here",
@@ -212,8 +221,9 @@ func TestGetRoleBindingFromTemplate(t *testing.T) {
 						Name:      "name",
 						Namespace: "another-namespace-2",
 						Labels: map[string]string{
-							"the-label":     "the-value",
-							label.ManagedBy: project.Name(),
+							"the-label":                  "the-value",
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "another-name",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "There is already a note here",
@@ -257,7 +267,7 @@ func TestGetRoleBindingFromTemplate(t *testing.T) {
 			var results []*rbacv1.RoleBinding
 
 			for _, namespace := range tc.Namespaces {
-				result, err := getRoleBindingFromTemplate(template, namespace)
+				result, err := getRoleBindingFromTemplate(template, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
 				if !tc.expectError && err != nil {
 					t.Fatalf("Expected success, got error %v", err)
 				}
@@ -290,6 +300,77 @@ func TestGetRoleBindingFromTemplate(t *testing.T) {
 	}
 }
 
+func TestGetRoleBindingFromTemplateWithPlaceholders(t *testing.T) {
+	template := v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "something",
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "{{ .Organization }}-admins",
+					Labels: map[string]string{
+						"kind": "{{ .NamespaceKind }}",
+					},
+					Annotations: map[string]string{
+						"cluster": "{{ .ClusterName }}",
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					Name: "cluster-admin",
+					Kind: "ClusterRole",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:{{ .Organization }}:admins"},
+					{Kind: "ServiceAccount", Name: "{{ .Namespace }}-automation"},
+				},
+			},
+		},
+	}
+
+	expected := &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-admins",
+			Namespace: "abc12",
+			Labels: map[string]string{
+				"kind":                       "cluster",
+				label.ManagedBy:              project.Name(),
+				pkglabel.RoleBindingTemplate: "something",
+			},
+			Annotations: map[string]string{
+				"cluster":        "abc12",
+				annotation.Notes: "Generated based on RoleBindingTemplate something",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     "cluster-admin",
+			Kind:     "ClusterRole",
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: "Group", Name: "customer:example:admins"},
+			{Kind: "ServiceAccount", Name: "abc12-automation", Namespace: "abc12"},
+		},
+	}
+
+	result, err := getRoleBindingFromTemplate(template, *test.NewClusterNamespace("abc12", "example"))
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected\n%v\n\n...to be equal to:\n%v\n", result, expected)
+	}
+
+	// the template itself must not be modified by rendering
+	if template.Spec.Template.Labels["kind"] != "{{ .NamespaceKind }}" {
+		t.Fatalf("Expected template labels to be unchanged, got %v", template.Spec.Template.Labels)
+	}
+}
+
 func TestEnsureCreated(t *testing.T) {
 	testCases := []struct {
 		Name          string
@@ -412,7 +493,8 @@ func TestEnsureCreated(t *testing.T) {
 						Name:      "something",
 						Namespace: "org-example",
 						Labels: map[string]string{
-							label.ManagedBy: project.Name(),
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "something",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -461,7 +543,8 @@ func TestEnsureCreated(t *testing.T) {
 						Name:      "something",
 						Namespace: "org-giantswarm",
 						Labels: map[string]string{
-							label.ManagedBy: project.Name(),
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "something",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -485,7 +568,8 @@ func TestEnsureCreated(t *testing.T) {
 						Name:      "something",
 						Namespace: "org-example",
 						Labels: map[string]string{
-							label.ManagedBy: project.Name(),
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "something",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -538,7 +622,8 @@ func TestEnsureCreated(t *testing.T) {
 						Name:      "something",
 						Namespace: "org-example",
 						Labels: map[string]string{
-							label.ManagedBy: project.Name(),
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "something",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -566,7 +651,8 @@ func TestEnsureCreated(t *testing.T) {
 						Name:      "something",
 						Namespace: "org-giantswarm",
 						Labels: map[string]string{
-							label.ManagedBy: project.Name(),
+							label.ManagedBy:              project.Name(),
+							pkglabel.RoleBindingTemplate: "something",
 						},
 						Annotations: map[string]string{
 							annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -653,6 +739,208 @@ func TestEnsureCreated(t *testing.T) {
 	}
 }
 
+func TestEnsureCreatedStatus(t *testing.T) {
+	testCases := []struct {
+		Name                 string
+		RoleRef              rbacv1.RoleRef
+		Organizations        []string
+		ForbiddenNamespaces  []string
+		ExistingNamespaces   []string
+		expectedNamespaces   []string
+		expectedFailed       []v1alpha1.FailedNamespace
+		expectedReady        metav1.ConditionStatus
+		expectedReadyReason  string
+		expectedDegraded     metav1.ConditionStatus
+		expectScopeCondition bool
+		expectError          bool
+	}{
+		{
+			Name:                 "case0: applied to all namespaces",
+			RoleRef:              rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+			Organizations:        []string{"example", "example-2"},
+			expectedNamespaces:   []string{"org-example", "org-example-2"},
+			expectedReady:        metav1.ConditionTrue,
+			expectedReadyReason:  "Applied",
+			expectedDegraded:     metav1.ConditionFalse,
+			expectScopeCondition: true,
+		},
+		{
+			Name:                "case1: rejected in one namespace",
+			RoleRef:             rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+			Organizations:       []string{"example", "example-2"},
+			ForbiddenNamespaces: []string{"org-example-2"},
+			expectedNamespaces:  []string{"org-example"},
+			expectedFailed: []v1alpha1.FailedNamespace{
+				{
+					Namespace: "org-example-2",
+					Reason:    string(metav1.StatusReasonForbidden),
+					Message:   `rolebindings.rbac.authorization.k8s.io "something" is forbidden: not allowed`,
+				},
+			},
+			expectedReady:        metav1.ConditionFalse,
+			expectedReadyReason:  "ApplyFailed",
+			expectedDegraded:     metav1.ConditionTrue,
+			expectScopeCondition: true,
+		},
+		{
+			Name:                "case2: existing role binding is kept when it can not be updated",
+			RoleRef:             rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+			Organizations:       []string{"example", "example-2"},
+			ForbiddenNamespaces: []string{"org-example-2"},
+			ExistingNamespaces:  []string{"org-example", "org-example-2"},
+			expectedNamespaces:  []string{"org-example"},
+			expectedFailed: []v1alpha1.FailedNamespace{
+				{
+					Namespace: "org-example-2",
+					Reason:    string(metav1.StatusReasonForbidden),
+					Message:   `rolebindings.rbac.authorization.k8s.io "something" is forbidden: not allowed`,
+				},
+			},
+			expectedReady:        metav1.ConditionFalse,
+			expectedReadyReason:  "ApplyFailed",
+			expectedDegraded:     metav1.ConditionTrue,
+			expectScopeCondition: true,
+		},
+		{
+			Name:                 "case3: invalid template",
+			RoleRef:              rbacv1.RoleRef{Name: "example"},
+			Organizations:        []string{"example"},
+			expectedReady:        metav1.ConditionFalse,
+			expectedReadyReason:  "InvalidTemplate",
+			expectScopeCondition: true,
+			expectError:          true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "something",
+					Generation: 3,
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef: tc.RoleRef,
+						Subjects: []rbacv1.Subject{
+							{Kind: "Group", Name: "test-group"},
+						},
+					},
+				},
+				Status: v1alpha1.RoleBindingTemplateStatus{
+					Namespaces: tc.ExistingNamespaces,
+				},
+			}
+			objects := []runtime.Object{template}
+			namespaces := []runtime.Object{}
+			for _, org := range tc.Organizations {
+				objects = append(objects, getTestOrganization(org))
+				namespace := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "org-" + org,
+					},
+				}
+				namespaces = append(namespaces, namespace)
+
+				if contains(tc.ExistingNamespaces, namespace.Name) {
+					roleBinding, err := getRoleBindingFromTemplate(*template, *namespace)
+					if err != nil {
+						t.Fatal(err)
+					}
+					roleBinding.Subjects = []rbacv1.Subject{{Kind: "Group", Name: "previous-group"}}
+					namespaces = append(namespaces, roleBinding)
+				}
+			}
+
+			k8sClient := clientgofake.NewSimpleClientset(namespaces...)
+			forbidden := func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if contains(tc.ForbiddenNamespaces, action.GetNamespace()) {
+					return true, nil, apierrors.NewForbidden(rbacv1.Resource("rolebindings"), "something", errors.New("not allowed"))
+				}
+				return false, nil, nil
+			}
+			k8sClient.PrependReactor("create", "rolebindings", forbidden)
+			k8sClient.PrependReactor("update", "rolebindings", forbidden)
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(objects...).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: k8sClient,
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, template)
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if tc.expectError && err == nil {
+				t.Fatalf("Expected error, got success")
+			}
+
+			result := &v1alpha1.RoleBindingTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+			if result.Status.ObservedGeneration != template.Generation {
+				t.Fatalf("Expected observedGeneration %d, got %d", template.Generation, result.Status.ObservedGeneration)
+			}
+			if !tc.expectError {
+				if !reflect.DeepEqual(tc.expectedNamespaces, result.Status.Namespaces) {
+					t.Fatalf("Expected namespaces %v, got %v", tc.expectedNamespaces, result.Status.Namespaces)
+				}
+				if !reflect.DeepEqual(tc.expectedFailed, result.Status.FailedNamespaces) {
+					t.Fatalf("Expected failed namespaces %v, got %v", tc.expectedFailed, result.Status.FailedNamespaces)
+				}
+				degraded := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeDegraded)
+				if degraded == nil || degraded.Status != tc.expectedDegraded {
+					t.Fatalf("Expected Degraded condition %s, got %v", tc.expectedDegraded, degraded)
+				}
+				for _, failed := range tc.expectedFailed {
+					if !contains(tc.ExistingNamespaces, failed.Namespace) {
+						continue
+					}
+					_, err = k8sClient.RbacV1().RoleBindings(failed.Namespace).Get(ctx, template.Name, metav1.GetOptions{})
+					if err != nil {
+						t.Fatalf("Expected role binding in failed namespace %s to be kept, got %v", failed.Namespace, err)
+					}
+				}
+			}
+			ready := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeReady)
+			if ready == nil || ready.Status != tc.expectedReady || ready.Reason != tc.expectedReadyReason {
+				t.Fatalf("Expected Ready condition %s with reason %s, got %v", tc.expectedReady, tc.expectedReadyReason, ready)
+			}
+			if ready.ObservedGeneration != template.Generation {
+				t.Fatalf("Expected Ready condition observedGeneration %d, got %d", template.Generation, ready.ObservedGeneration)
+			}
+			if tc.expectScopeCondition && !meta.IsStatusConditionTrue(result.Status.Conditions, v1alpha1.ConditionTypeScopeResolved) {
+				t.Fatalf("Expected ScopeResolved condition to be true, got %v", result.Status.Conditions)
+			}
+		})
+	}
+}
+
 func getTestOrganization(name string) *security.Organization {
 	return &security.Organization{
 		ObjectMeta: metav1.ObjectMeta{
@@ -673,7 +961,8 @@ func getTestRoleBinding() *rbacv1.RoleBinding {
 			Name:      "something",
 			Namespace: "org-example",
 			Labels: map[string]string{
-				label.ManagedBy: project.Name(),
+				label.ManagedBy:              project.Name(),
+				pkglabel.RoleBindingTemplate: "something",
 			},
 			Annotations: map[string]string{
 				annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -701,7 +990,8 @@ func getTestRoleBindingInNS(namespace string) *rbacv1.RoleBinding {
 			Name:      "something",
 			Namespace: "org-example",
 			Labels: map[string]string{
-				label.ManagedBy: project.Name(),
+				label.ManagedBy:              project.Name(),
+				pkglabel.RoleBindingTemplate: "something",
 			},
 			Annotations: map[string]string{
 				annotation.Notes: "Generated based on RoleBindingTemplate something",
@@ -4,7 +4,16 @@ import (
 	"context"
 
 	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/pause"
+	"github.com/giantswarm/rbac-operator/pkg/project"
 	"github.com/giantswarm/rbac-operator/pkg/rbac"
 	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/key"
 )
@@ -15,13 +24,132 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	roleBindingName := getRoleBindingNameFromTemplate(template)
+	r.cancelRequeue(template)
+
+	if pause.IsPaused(&template) {
+		pause.Skip(r.logger, ctx, "RoleBindingTemplate", "", template.Name)
+		return nil
+	}
 
 	for _, ns := range template.Status.Namespaces {
-		if err = rbac.DeleteRoleBinding(r, ctx, ns, roleBindingName); err != nil {
+		if err = r.deleteRoleBinding(ctx, template, ns); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	if err = r.deleteOwnedRoleBindings(ctx, template, nil); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// deleteRoleBinding deletes the role binding rendered from the template in the given namespace.
+func (r *Resource) deleteRoleBinding(ctx context.Context, template v1alpha1.RoleBindingTemplate, ns string) error {
+	roleBinding, err := r.getRoleBindingToDelete(ctx, template, ns)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if roleBinding == nil {
+		return nil
+	}
+
+	if isMerged(roleBinding) {
+		if err = r.withdrawContribution(ctx, template, roleBinding); err != nil {
 			return microerror.Mask(err)
 		}
+		return nil
+	}
+
+	if err = rbac.DeleteRoleBinding(r, ctx, ns, roleBinding.Name); err != nil {
+		return microerror.Mask(err)
 	}
 
 	return nil
 }
+
+// getRoleBindingToDelete returns the role binding rendered from the template in the given namespace.
+// Nothing needs to be done for namespaces or role bindings which no longer exist, and role bindings
+// not created by rbac-operator are left untouched, e.g. the ones skipped due to the adoption policy,
+// as are the ones rendered by other templates.
+func (r *Resource) getRoleBindingToDelete(ctx context.Context, template v1alpha1.RoleBindingTemplate, ns string) (*rbacv1.RoleBinding, error) {
+	namespace, err := r.k8sClient.K8sClient().CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	roleBindingName, err := getRoleBindingNameForNamespace(template, *namespace)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	roleBinding, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(ns).Get(ctx, roleBindingName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	if !isManaged(roleBinding) {
+		r.logger.Debugf(ctx, "Not deleting roleBinding %s in namespace %s as it is not managed by %s", roleBindingName, ns, project.Name())
+		return nil, nil
+	}
+	if !isMerged(roleBinding) && isOwnedByOtherTemplate(template, roleBinding) {
+		r.logger.Debugf(ctx, "Not deleting roleBinding %s in namespace %s as it is rendered by another RoleBindingTemplate", roleBindingName, ns)
+		return nil, nil
+	}
+
+	return roleBinding, nil
+}
+
+// deleteOwnedRoleBindings deletes the role bindings carrying the owner labels of the template in all
+// namespaces, except for the ones to keep. The contributions of the template to merged role bindings
+// are withdrawn.
+func (r *Resource) deleteOwnedRoleBindings(ctx context.Context, template v1alpha1.RoleBindingTemplate, keep map[types.NamespacedName]bool) error {
+	roleBindings, err := r.getOwnedRoleBindings(ctx, template)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, roleBinding := range roleBindings {
+		if keep[types.NamespacedName{Namespace: roleBinding.Namespace, Name: roleBinding.Name}] {
+			continue
+		}
+		if err = rbac.DeleteRoleBinding(r, ctx, roleBinding.Namespace, roleBinding.Name); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	merged, err := r.getMergedRoleBindings(ctx, template)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range merged {
+		if keep[types.NamespacedName{Namespace: merged[i].Namespace, Name: merged[i].Name}] {
+			continue
+		}
+		if err = r.withdrawContribution(ctx, template, &merged[i]); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// getOwnedRoleBindings returns the role bindings carrying the owner labels of the template in all
+// namespaces. Templates without UID own no role bindings.
+func (r *Resource) getOwnedRoleBindings(ctx context.Context, template v1alpha1.RoleBindingTemplate) ([]rbacv1.RoleBinding, error) {
+	if template.UID == "" {
+		return nil, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{pkglabel.RoleBindingTemplateUID: string(template.UID)})
+	roleBindings, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return roleBindings.Items, nil
+}
@@ -0,0 +1,249 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+func TestOwnedRoleBindingsSweep(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Delete bool
+
+		expectedRoleBindings []string
+	}{
+		{
+			Name: "case0: remove orphaned role bindings on create",
+			expectedRoleBindings: []string{
+				"org-example/foreign",
+				"org-example/something",
+				"org-other/foreign",
+			},
+		},
+		{
+			Name:   "case1: remove all owned role bindings on delete",
+			Delete: true,
+			expectedRoleBindings: []string{
+				"org-example/foreign",
+				"org-other/foreign",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "something",
+					UID:  types.UID("template-uid"),
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+						Subjects: []rbacv1.Subject{
+							{Kind: "Group", Name: "test-group"},
+						},
+					},
+					Scopes: v1alpha1.RoleBindingTemplateScopes{
+						OrganizationSelector: v1alpha1.ScopeSelector{
+							MatchLabels: map[string]string{"name": "example"},
+						},
+					},
+				},
+			}
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(template, getTestOrganization("example"), getTestOrganization("other")).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-other"}},
+						getTestOwnedRoleBinding("org-example", "previous-name", "template-uid"),
+						getTestOwnedRoleBinding("org-other", "something", "template-uid"),
+						getTestOwnedRoleBinding("org-example", "foreign", "another-uid"),
+						getTestOwnedRoleBinding("org-other", "foreign", ""),
+					),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			if tc.Delete {
+				err = r.EnsureDeleted(ctx, template)
+			} else {
+				err = r.EnsureCreated(ctx, template)
+			}
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			roleBindings, err := k8sClientFake.K8sClient().RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var result []string
+			for _, roleBinding := range roleBindings.Items {
+				result = append(result, roleBinding.Namespace+"/"+roleBinding.Name)
+			}
+			sort.Strings(result)
+			if !reflect.DeepEqual(tc.expectedRoleBindings, result) {
+				t.Fatalf("Expected role bindings %v, got %v", tc.expectedRoleBindings, result)
+			}
+		})
+	}
+}
+
+// TestUnlabelledRoleBindingSweep ensures role bindings generated before they were labelled with the
+// owner UID get the labels once the template is reconciled, so that they are swept when leaving the scope.
+func TestUnlabelledRoleBindingSweep(t *testing.T) {
+	template := &v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "something",
+			UID:  types.UID("template-uid"),
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole", APIGroup: "rbac.authorization.k8s.io"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchLabels: map[string]string{"name": "example"},
+				},
+			},
+		},
+	}
+
+	// generated before owner labels were added, hence only labelled as managed
+	existing := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "something",
+			Namespace: "org-example",
+			Labels: map[string]string{
+				label.ManagedBy:              project.Name(),
+				pkglabel.RoleBindingTemplate: "something",
+			},
+			Annotations: map[string]string{
+				annotation.Notes: "Generated based on RoleBindingTemplate something",
+			},
+		},
+		RoleRef:  template.Spec.Template.RoleRef,
+		Subjects: template.Spec.Template.Subjects,
+	}
+
+	var k8sClientFake *k8sclienttest.Clients
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+		if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(template, getTestOrganization("example")).
+				WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+				Build(),
+			K8sClient: clientgofake.NewSimpleClientset(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+				existing,
+			),
+		})
+	}
+
+	r, err := New(Config{
+		K8sClient: k8sClientFake,
+		Logger:    microloggertest.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err = r.EnsureCreated(ctx, template); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	roleBinding, err := k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "something", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roleBinding.Labels[pkglabel.RoleBindingTemplateUID] != "template-uid" {
+		t.Fatalf("Expected role binding to be labelled with the template UID, got labels %v", roleBinding.Labels)
+	}
+
+	// the namespace leaves the scope while the status lost track of it
+	if err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKeyFromObject(template), template); err != nil {
+		t.Fatal(err)
+	}
+	template.Spec.Scopes.OrganizationSelector.MatchLabels = map[string]string{"name": "other"}
+	template.Status.Namespaces = nil
+	if err = r.EnsureCreated(ctx, template); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "something", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected role binding to be swept, got error %v", err)
+	}
+}
+
+func getTestOwnedRoleBinding(namespace, name, uid string) *rbacv1.RoleBinding {
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+	}
+	if uid != "" {
+		roleBinding.Labels[pkglabel.RoleBindingTemplateUID] = uid
+	}
+	return roleBinding
+}
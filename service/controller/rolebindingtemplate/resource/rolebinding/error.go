@@ -12,3 +12,12 @@ var invalidConfigError = &microerror.Error{
 func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
+
+var collisionError = &microerror.Error{
+	Kind: "collisionError",
+}
+
+// IsCollision asserts collisionError.
+func IsCollision(err error) bool {
+	return microerror.Cause(err) == collisionError
+}
@@ -0,0 +1,240 @@
+package rolebinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgannotation "github.com/giantswarm/rbac-operator/pkg/annotation"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+)
+
+// contributions maps the names of the templates merged into a role binding to the subjects they contributed
+type contributions map[string][]rbacv1.Subject
+
+// applyRoleBinding creates or updates the role binding rendered from the template once collisions
+// with the existing one are resolved. Updates are based on the resource version of the existing role
+// binding, so that subjects merged from templates reconciled at the same time are not lost, and are
+// retried with the merged subjects of the latest version on conflicts.
+func (r *Resource) applyRoleBinding(ctx context.Context, template v1alpha1.RoleBindingTemplate, roleBinding *rbacv1.RoleBinding) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		resolved, err := r.resolveCollision(ctx, template, roleBinding)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, roleBinding.Namespace, resolved)
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// resolveCollision returns the role binding to apply for the template. Role bindings owned by other
+// templates are only merged if the template uses the Union merge strategy, otherwise a collision
+// error is returned and the existing role binding is left untouched. The returned role binding carries
+// the resource version of the existing one.
+func (r *Resource) resolveCollision(ctx context.Context, template v1alpha1.RoleBindingTemplate, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	existing, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(roleBinding.Namespace).Get(ctx, roleBinding.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if template.Spec.MergeStrategy != v1alpha1.MergeStrategyUnion {
+		if existing != nil && isOwnedByOtherTemplate(template, existing) {
+			return nil, microerror.Maskf(collisionError, "RoleBinding %s is rendered by another RoleBindingTemplate, use mergeStrategy Union on all of them to merge their subjects", roleBinding.Name)
+		}
+		roleBinding = roleBinding.DeepCopy()
+		if existing != nil {
+			roleBinding.ResourceVersion = existing.ResourceVersion
+		}
+		return roleBinding, nil
+	}
+
+	merged := contributions{}
+	if existing != nil && isManaged(existing) {
+		if isMerged(existing) {
+			if existing.RoleRef != roleBinding.RoleRef {
+				return nil, microerror.Maskf(collisionError, "RoleBinding %s merges subjects for %s %s", roleBinding.Name, existing.RoleRef.Kind, existing.RoleRef.Name)
+			}
+			merged, err = getContributions(existing)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		} else if isOwnedByOtherTemplate(template, existing) {
+			return nil, microerror.Maskf(collisionError, "RoleBinding %s is rendered by a RoleBindingTemplate which does not use mergeStrategy Union", roleBinding.Name)
+		}
+	}
+	merged[template.Name] = roleBinding.Subjects
+
+	roleBinding = roleBinding.DeepCopy()
+	if existing != nil {
+		roleBinding.ResourceVersion = existing.ResourceVersion
+	}
+	if existing != nil && isMerged(existing) {
+		// labels and annotations of the other contributing templates are kept
+		roleBinding.Labels = mergeMaps(existing.Labels, roleBinding.Labels)
+		roleBinding.Annotations = mergeMaps(existing.Annotations, roleBinding.Annotations)
+	}
+	delete(roleBinding.Labels, pkglabel.RoleBindingTemplate)
+	delete(roleBinding.Labels, pkglabel.RoleBindingTemplateUID)
+	roleBinding.Labels[pkglabel.RoleBindingTemplateMerged] = "true"
+	if err = setContributions(roleBinding, merged); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return roleBinding, nil
+}
+
+// withdrawContribution removes the subjects contributed by the template from a merged role binding,
+// which is deleted once no template contributes to it anymore.
+func (r *Resource) withdrawContribution(ctx context.Context, template v1alpha1.RoleBindingTemplate, roleBinding *rbacv1.RoleBinding) error {
+	merged, err := getContributions(roleBinding)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if _, ok := merged[template.Name]; !ok {
+		return nil
+	}
+	delete(merged, template.Name)
+
+	if len(merged) == 0 {
+		if err = rbac.DeleteRoleBinding(r, ctx, roleBinding.Namespace, roleBinding.Name); err != nil {
+			return microerror.Mask(err)
+		}
+		return nil
+	}
+
+	roleBinding = roleBinding.DeepCopy()
+	if err = setContributions(roleBinding, merged); err != nil {
+		return microerror.Mask(err)
+	}
+	if err = rbac.CreateOrUpdateRoleBinding(r, ctx, r.protection, roleBinding.Namespace, roleBinding); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getMergedRoleBindings returns the merged role bindings the template contributes to in all namespaces.
+func (r *Resource) getMergedRoleBindings(ctx context.Context, template v1alpha1.RoleBindingTemplate) ([]rbacv1.RoleBinding, error) {
+	selector := labels.SelectorFromSet(labels.Set{pkglabel.RoleBindingTemplateMerged: "true"})
+	roleBindings, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var contributing []rbacv1.RoleBinding
+	for _, roleBinding := range roleBindings.Items {
+		merged, err := getContributions(&roleBinding)
+		if err != nil {
+			r.logger.Debugf(ctx, "Could not read contributions to roleBinding %s in namespace %s due to error %v", roleBinding.Name, roleBinding.Namespace, err)
+			continue
+		}
+		if _, ok := merged[template.Name]; ok {
+			contributing = append(contributing, roleBinding)
+		}
+	}
+
+	return contributing, nil
+}
+
+// isMerged reports whether the subjects of the role binding are merged from several templates
+func isMerged(roleBinding *rbacv1.RoleBinding) bool {
+	return roleBinding.Labels[pkglabel.RoleBindingTemplateMerged] == "true"
+}
+
+// isOwnedByOtherTemplate reports whether the role binding was rendered by a different template, or
+// other templates contributed to it in case it is merged
+func isOwnedByOtherTemplate(template v1alpha1.RoleBindingTemplate, roleBinding *rbacv1.RoleBinding) bool {
+	if !isManaged(roleBinding) {
+		return false
+	}
+	if isMerged(roleBinding) {
+		merged, err := getContributions(roleBinding)
+		if err != nil {
+			return true
+		}
+		for name := range merged {
+			if name != template.Name {
+				return true
+			}
+		}
+		return false
+	}
+	uid := roleBinding.Labels[pkglabel.RoleBindingTemplateUID]
+	return uid != "" && uid != string(template.UID)
+}
+
+func getContributions(roleBinding *rbacv1.RoleBinding) (contributions, error) {
+	merged := contributions{}
+
+	value := roleBinding.Annotations[pkgannotation.RoleBindingTemplateSubjects]
+	if value == "" {
+		return merged, nil
+	}
+	if err := json.Unmarshal([]byte(value), &merged); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return merged, nil
+}
+
+// setContributions records the contributions in the annotations of the role binding and binds the
+// union of their subjects, ordered by template name.
+func setContributions(roleBinding *rbacv1.RoleBinding, merged contributions) error {
+	value, err := json.Marshal(merged)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if roleBinding.Annotations == nil {
+		roleBinding.Annotations = map[string]string{}
+	}
+	roleBinding.Annotations[pkgannotation.RoleBindingTemplateSubjects] = string(value)
+
+	var names []string
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	roleBinding.Annotations[annotation.Notes] = fmt.Sprintf("Generated based on RoleBindingTemplates %s", strings.Join(names, ", "))
+
+	var subjects []rbacv1.Subject
+	for _, name := range names {
+		for _, subject := range merged[name] {
+			if !containsSubject(subjects, subject) {
+				subjects = append(subjects, subject)
+			}
+		}
+	}
+	roleBinding.Subjects = subjects
+
+	return nil
+}
+
+func mergeMaps(base, overlay map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
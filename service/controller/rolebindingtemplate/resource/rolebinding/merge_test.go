@@ -0,0 +1,256 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+)
+
+func getMergeTestTemplate(name string, strategy v1alpha1.MergeStrategy, subjects ...string) *v1alpha1.RoleBindingTemplate {
+	template := &v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			UID:  types.UID(name + "-uid"),
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				RoleRef:    rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchLabels: map[string]string{"name": "example"},
+				},
+			},
+			MergeStrategy: strategy,
+		},
+	}
+	for _, subject := range subjects {
+		template.Spec.Template.Subjects = append(template.Spec.Template.Subjects, rbacv1.Subject{Kind: "Group", Name: subject})
+	}
+	return template
+}
+
+func TestMergeStrategy(t *testing.T) {
+	first := getMergeTestTemplate("first", v1alpha1.MergeStrategyUnion, "group-a", "group-shared")
+	second := getMergeTestTemplate("second", v1alpha1.MergeStrategyUnion, "group-b", "group-shared")
+	third := getMergeTestTemplate("third", v1alpha1.MergeStrategyNone, "group-c")
+
+	var k8sClientFake *k8sclienttest.Clients
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+		if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(first, second, third, getTestOrganization("example")).
+				WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+				Build(),
+			K8sClient: clientgofake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}}),
+		})
+	}
+
+	r, err := New(Config{
+		K8sClient: k8sClientFake,
+		Logger:    microloggertest.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	getTemplate := func(name string) *v1alpha1.RoleBindingTemplate {
+		template := &v1alpha1.RoleBindingTemplate{}
+		if err := k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			t.Fatalf("failed to get template: %s", err)
+		}
+		return template
+	}
+	expectSubjects := func(expected ...string) {
+		roleBinding, err := k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "shared", metav1.GetOptions{})
+		if len(expected) == 0 {
+			if !apierrors.IsNotFound(err) {
+				t.Fatalf("Expected roleBinding to be deleted, got %v", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to get roleBinding: %s", err)
+		}
+		var subjects []string
+		for _, subject := range roleBinding.Subjects {
+			subjects = append(subjects, subject.Name)
+		}
+		if !reflect.DeepEqual(expected, subjects) {
+			t.Fatalf("Expected subjects %v, got %v", expected, subjects)
+		}
+		if !isMerged(roleBinding) || roleBinding.Labels[pkglabel.RoleBindingTemplateUID] != "" {
+			t.Fatalf("Expected roleBinding to be labelled as merged, got labels %v", roleBinding.Labels)
+		}
+	}
+
+	// both templates contribute their subjects
+	if err = r.EnsureCreated(ctx, getTemplate("first")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	if err = r.EnsureCreated(ctx, getTemplate("second")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects("group-a", "group-shared", "group-b")
+
+	// reconciling again is stable
+	if err = r.EnsureCreated(ctx, getTemplate("first")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects("group-a", "group-shared", "group-b")
+
+	// a template which does not merge reports a collision and leaves the role binding alone
+	if err = r.EnsureCreated(ctx, getTemplate("third")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects("group-a", "group-shared", "group-b")
+	status := getTemplate("third").Status
+	if len(status.FailedNamespaces) != 1 || status.FailedNamespaces[0].Reason != "Collision" {
+		t.Fatalf("Expected collision in org-example, got %v", status.FailedNamespaces)
+	}
+	if err = r.EnsureDeleted(ctx, getTemplate("third")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects("group-a", "group-shared", "group-b")
+
+	// removing a template only removes the subjects it contributed alone
+	if err = r.EnsureDeleted(ctx, getTemplate("first")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects("group-b", "group-shared")
+
+	// the role binding is deleted with the last contributing template
+	if err = r.EnsureDeleted(ctx, getTemplate("second")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	expectSubjects()
+}
+
+func TestMergeStrategyConflict(t *testing.T) {
+	first := getMergeTestTemplate("first", v1alpha1.MergeStrategyUnion, "group-a", "group-shared")
+	second := getMergeTestTemplate("second", v1alpha1.MergeStrategyUnion, "group-b", "group-shared")
+	third := getMergeTestTemplate("third", v1alpha1.MergeStrategyUnion, "group-c")
+
+	var k8sClientFake *k8sclienttest.Clients
+	var clientset *clientgofake.Clientset
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+		if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		clientset = clientgofake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}})
+		k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(first, second, third, getTestOrganization("example")).
+				WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+				Build(),
+			K8sClient: clientset,
+		})
+	}
+
+	r, err := New(Config{
+		K8sClient: k8sClientFake,
+		Logger:    microloggertest.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	getTemplate := func(name string) *v1alpha1.RoleBindingTemplate {
+		template := &v1alpha1.RoleBindingTemplate{}
+		if err := k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			t.Fatalf("failed to get template: %s", err)
+		}
+		return template
+	}
+
+	if err = r.EnsureCreated(ctx, getTemplate("first")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	// another template merges its subjects while the second one is applied, so the update of the
+	// second one is based on an outdated role binding and conflicts
+	conflicted := false
+	clientset.PrependReactor("update", "rolebindings", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+
+		gvr := rbacv1.SchemeGroupVersion.WithResource("rolebindings")
+		obj, err := clientset.Tracker().Get(gvr, "org-example", "shared")
+		if err != nil {
+			t.Fatalf("failed to get roleBinding: %s", err)
+		}
+		roleBinding := obj.(*rbacv1.RoleBinding).DeepCopy()
+		merged, err := getContributions(roleBinding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		merged["third"] = third.Spec.Template.Subjects
+		if err = setContributions(roleBinding, merged); err != nil {
+			t.Fatal(err)
+		}
+		if err = clientset.Tracker().Update(gvr, roleBinding, "org-example"); err != nil {
+			t.Fatalf("failed to update roleBinding: %s", err)
+		}
+		return true, nil, apierrors.NewConflict(rbacv1.Resource("rolebindings"), "shared", nil)
+	})
+	if err = r.EnsureCreated(ctx, getTemplate("second")); err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+	if !conflicted {
+		t.Fatal("Expected the update to conflict")
+	}
+
+	if failed := getTemplate("second").Status.FailedNamespaces; len(failed) != 0 {
+		t.Fatalf("Expected no failed namespaces, got %v", failed)
+	}
+	roleBinding, err := clientset.RbacV1().RoleBindings("org-example").Get(ctx, "shared", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get roleBinding: %s", err)
+	}
+	var subjects []string
+	for _, subject := range roleBinding.Subjects {
+		subjects = append(subjects, subject.Name)
+	}
+	expected := []string{"group-a", "group-shared", "group-b", "group-c"}
+	if !reflect.DeepEqual(expected, subjects) {
+		t.Fatalf("Expected subjects %v, got %v", expected, subjects)
+	}
+}
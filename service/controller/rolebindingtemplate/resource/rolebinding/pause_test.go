@@ -0,0 +1,217 @@
+package rolebinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgannotation "github.com/giantswarm/rbac-operator/pkg/annotation"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+func TestPausedTemplate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		delete bool
+	}{
+		{
+			name: "case 0: paused template does not update its role bindings",
+		},
+		{
+			name:   "case 1: paused template does not delete its role bindings",
+			delete: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "something",
+					Annotations: map[string]string{pkgannotation.Paused: "true"},
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef:  rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+						Subjects: []rbacv1.Subject{{Kind: "Group", Name: "test-group"}},
+					},
+					Scopes: v1alpha1.RoleBindingTemplateScopes{
+						OrganizationSelector: v1alpha1.ScopeSelector{
+							MatchLabels: map[string]string{"name": "example"},
+						},
+					},
+				},
+				Status: v1alpha1.RoleBindingTemplateStatus{
+					Namespaces: []string{"org-example"},
+				},
+			}
+
+			existing := []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-example", Labels: map[string]string{label.ManagedBy: project.Name()}},
+					Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "hand-edited"}},
+				},
+			}
+
+			var k8sClient *clientgofake.Clientset
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClient = clientgofake.NewSimpleClientset(existing...)
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(template, getTestOrganization("example")).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: k8sClient,
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			if tc.delete {
+				err = r.EnsureDeleted(ctx, template)
+			} else {
+				err = r.EnsureCreated(ctx, template)
+			}
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			for _, action := range k8sClient.Actions() {
+				if action.GetVerb() != "get" && action.GetVerb() != "list" {
+					t.Fatalf("Expected no changes for paused template, got %s %s", action.GetVerb(), action.GetResource().Resource)
+				}
+			}
+
+			if tc.delete {
+				return
+			}
+
+			result := &v1alpha1.RoleBindingTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+
+			ready := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeReady)
+			if ready == nil || ready.Reason != "Paused" {
+				t.Fatalf("Expected Ready condition with reason Paused, got %v", ready)
+			}
+		})
+	}
+}
+
+func TestPausedNamespace(t *testing.T) {
+	template := &v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "something",
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef:  rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{{Kind: "Group", Name: "test-group"}},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchLabels: map[string]string{"name": "example"},
+				},
+			},
+		},
+		Status: v1alpha1.RoleBindingTemplateStatus{
+			Namespaces: []string{"org-example"},
+		},
+	}
+
+	existing := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example", Annotations: map[string]string{pkgannotation.Paused: "true"}}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-example", Labels: map[string]string{label.ManagedBy: project.Name()}},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "hand-edited"}},
+		},
+	}
+
+	var k8sClient *clientgofake.Clientset
+	var k8sClientFake *k8sclienttest.Clients
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+		if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		k8sClient = clientgofake.NewSimpleClientset(existing...)
+		k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(template, getTestOrganization("example")).
+				WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+				Build(),
+			K8sClient: k8sClient,
+		})
+	}
+
+	r, err := New(Config{
+		K8sClient: k8sClientFake,
+		Logger:    microloggertest.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = r.EnsureCreated(ctx, template)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	for _, action := range k8sClient.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Fatalf("Expected no changes in paused namespace, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	result := &v1alpha1.RoleBindingTemplate{}
+	err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+	if err != nil {
+		t.Fatalf("failed to get template: %s", err)
+	}
+	if len(result.Status.Namespaces) != 0 {
+		t.Fatalf("Expected no applied namespaces, got %v", result.Status.Namespaces)
+	}
+	if len(result.Status.PausedNamespaces) != 1 || result.Status.PausedNamespaces[0] != "org-example" {
+		t.Fatalf("Expected paused namespace org-example, got %v", result.Status.PausedNamespaces)
+	}
+}
@@ -0,0 +1,126 @@
+package rolebinding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+)
+
+// plan records the role bindings applying the template to the given namespaces would create, update
+// and delete in the status of the template, without making any changes to the role bindings
+func (r *Resource) plan(ctx context.Context, template v1alpha1.RoleBindingTemplate, namespaces []corev1.Namespace, sourced *sourcedSubjects) error {
+	plan := &v1alpha1.RoleBindingTemplatePlan{}
+
+	desired := map[types.NamespacedName]bool{}
+	for _, namespace := range namespaces {
+		ns := namespace.Name
+		roleBinding, err := getRoleBindingFromTemplate(template, namespace)
+		if err != nil {
+			setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "InvalidTemplate", err.Error())
+			r.updateStatus(ctx, &template)
+			return microerror.Mask(err)
+		}
+		if err = r.addSourcedSubjects(ctx, sourced, namespace, roleBinding); err != nil {
+			return microerror.Mask(err)
+		}
+
+		roleBinding = cleanSubjects(r.protection, roleBinding, ns)
+		if len(roleBinding.Subjects) == 0 {
+			continue
+		}
+
+		planned := v1alpha1.PlannedRoleBinding{Namespace: ns, Name: roleBinding.Name}
+		existing, err := r.k8sClient.K8sClient().RbacV1().RoleBindings(ns).Get(ctx, roleBinding.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			desired[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+			plan.Create = append(plan.Create, planned)
+			continue
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		// role bindings not created by rbac-operator are only overwritten when adopted
+		if !isManaged(existing) && template.Spec.AdoptionPolicy != v1alpha1.AdoptionPolicyAdopt {
+			continue
+		}
+		// role bindings rendered by other templates are only touched when merging subjects
+		roleBinding, err = r.resolveCollision(ctx, template, roleBinding)
+		if IsCollision(err) {
+			continue
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		desired[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+		if !isManaged(existing) || rbac.RoleBindingNeedsUpdate(roleBinding, existing) {
+			plan.Update = append(plan.Update, planned)
+		}
+	}
+
+	deleted := map[types.NamespacedName]rbacv1.RoleBinding{}
+	for _, ns := range template.Status.Namespaces {
+		roleBinding, err := r.getRoleBindingToDelete(ctx, template, ns)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if roleBinding == nil {
+			continue
+		}
+		deleted[types.NamespacedName{Namespace: roleBinding.Namespace, Name: roleBinding.Name}] = *roleBinding
+	}
+	owned, err := r.getOwnedRoleBindings(ctx, template)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	merged, err := r.getMergedRoleBindings(ctx, template)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	for _, roleBinding := range append(owned, merged...) {
+		deleted[types.NamespacedName{Namespace: roleBinding.Namespace, Name: roleBinding.Name}] = roleBinding
+	}
+	for name, roleBinding := range deleted {
+		if desired[name] {
+			continue
+		}
+		planned := v1alpha1.PlannedRoleBinding{Namespace: name.Namespace, Name: name.Name}
+		// merged role bindings are kept as long as other templates contribute to them
+		if isMerged(&roleBinding) && isOwnedByOtherTemplate(template, &roleBinding) {
+			plan.Update = append(plan.Update, planned)
+		} else {
+			plan.Delete = append(plan.Delete, planned)
+		}
+	}
+	sortPlanned(plan.Update)
+	sortPlanned(plan.Delete)
+
+	message := fmt.Sprintf("Plan mode, %d RoleBindings would be created, %d updated and %d deleted", len(plan.Create), len(plan.Update), len(plan.Delete))
+	setCondition(&template, v1alpha1.ConditionTypeReady, metav1.ConditionFalse, "Planned", message)
+	setCondition(&template, v1alpha1.ConditionTypeDegraded, metav1.ConditionFalse, "Planned", message)
+
+	template.Status.Plan = plan
+	template.Status.ObservedGeneration = template.Generation
+	if err := r.k8sClient.CtrlClient().Status().Update(ctx, &template); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func sortPlanned(planned []v1alpha1.PlannedRoleBinding) {
+	sort.Slice(planned, func(i, j int) bool {
+		if planned[i].Namespace != planned[j].Namespace {
+			return planned[i].Namespace < planned[j].Namespace
+		}
+		return planned[i].Name < planned[j].Name
+	})
+}
@@ -0,0 +1,150 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+func TestEnsureCreatedPlan(t *testing.T) {
+	template := &v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "something",
+			UID:  types.UID("template-uid"),
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "name", Operator: metav1.LabelSelectorOpIn, Values: []string{"example", "example-2", "example-3"}},
+					},
+				},
+			},
+			Mode: v1alpha1.RoleBindingTemplateModePlan,
+		},
+		Status: v1alpha1.RoleBindingTemplateStatus{
+			Namespaces: []string{"org-example", "org-old"},
+		},
+	}
+
+	managedLabels := map[string]string{
+		label.ManagedBy:                 project.Name(),
+		pkglabel.RoleBindingTemplateUID: string(template.UID),
+	}
+	existing := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example-2"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example-3"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-old"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-other"}},
+		// outdated subjects
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-example", Labels: managedLabels},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "previous-group"}},
+		},
+		// not managed by rbac-operator
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-example-3"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "customer-group"}},
+		},
+		// only known from the status
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-old", Labels: map[string]string{label.ManagedBy: project.Name()}},
+		},
+		// only known from the owner labels
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "something", Namespace: "org-other", Labels: managedLabels},
+		},
+	}
+
+	var k8sClient *clientgofake.Clientset
+	var k8sClientFake *k8sclienttest.Clients
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+		if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatal(err)
+		}
+
+		k8sClient = clientgofake.NewSimpleClientset(existing...)
+		k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(template, getTestOrganization("example"), getTestOrganization("example-2"), getTestOrganization("example-3")).
+				WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+				Build(),
+			K8sClient: k8sClient,
+		})
+	}
+
+	r, err := New(Config{
+		K8sClient: k8sClientFake,
+		Logger:    microloggertest.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	err = r.EnsureCreated(ctx, template)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	for _, action := range k8sClient.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Fatalf("Expected no changes in plan mode, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	result := &v1alpha1.RoleBindingTemplate{}
+	err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+	if err != nil {
+		t.Fatalf("failed to get template: %s", err)
+	}
+
+	expectedPlan := &v1alpha1.RoleBindingTemplatePlan{
+		Create: []v1alpha1.PlannedRoleBinding{{Namespace: "org-example-2", Name: "something"}},
+		Update: []v1alpha1.PlannedRoleBinding{{Namespace: "org-example", Name: "something"}},
+		Delete: []v1alpha1.PlannedRoleBinding{
+			{Namespace: "org-old", Name: "something"},
+			{Namespace: "org-other", Name: "something"},
+		},
+	}
+	if !reflect.DeepEqual(expectedPlan, result.Status.Plan) {
+		t.Fatalf("Expected plan %v, got %v", expectedPlan, result.Status.Plan)
+	}
+	if !reflect.DeepEqual(template.Status.Namespaces, result.Status.Namespaces) {
+		t.Fatalf("Expected namespaces %v to be retained, got %v", template.Status.Namespaces, result.Status.Namespaces)
+	}
+	ready := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeReady)
+	if ready == nil || ready.Reason != "Planned" {
+		t.Fatalf("Expected Ready condition with reason Planned, got %v", ready)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -18,6 +19,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
 )
 
 const (
@@ -27,11 +31,22 @@ const (
 type Config struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	// AccessGroups are bound by templates referencing them in subjectsFrom, none are bound when unset
+	AccessGroups *accessgroup.Store
+	// Scheduler requeues templates at the boundaries of their validity, they are not requeued when unset
+	Scheduler *resync.Scheduler
+	// Protection restricts the subjects bound in protected namespaces, it defaults to protection.DefaultPolicy()
+	Protection protection.Policy
 }
 
 type Resource struct {
 	k8sClient k8sclient.Interface
 	logger    micrologger.Logger
+
+	accessGroups *accessgroup.Store
+	scheduler    *resync.Scheduler
+	protection   protection.Policy
 }
 
 func New(config Config) (*Resource, error) {
@@ -42,9 +57,18 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
+	accessGroups := config.AccessGroups
+	if accessGroups == nil {
+		accessGroups = accessgroup.NewStore(accessgroup.AccessGroups{})
+	}
+
 	r := &Resource{
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
+
+		accessGroups: accessGroups,
+		scheduler:    config.Scheduler,
+		protection:   config.Protection.OrDefault(),
 	}
 
 	return r, nil
@@ -63,12 +87,29 @@ func (r *Resource) Name() string {
 }
 
 func getLabelSelectorFromScopes(scopes v1alpha1.RoleBindingTemplateScopes) (labels.Selector, error) {
+	return getLabelSelectorFromScopeSelector(scopes.OrganizationSelector)
+}
+
+func getLabelSelectorFromScopeSelector(selector v1alpha1.ScopeSelector) (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
-		MatchLabels:      scopes.OrganizationSelector.MatchLabels,
-		MatchExpressions: scopes.OrganizationSelector.MatchExpressions,
+		MatchLabels:      selector.MatchLabels,
+		MatchExpressions: selector.MatchExpressions,
 	})
 }
 
+// hasTarget reports whether the scopes include the given target, all targets are included when none are set
+func hasTarget(scopes v1alpha1.RoleBindingTemplateScopes, target v1alpha1.ScopeTarget) bool {
+	if len(scopes.Targets) == 0 {
+		return true
+	}
+	for _, t := range scopes.Targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Resource) getOrganizationsForLabelSelector(ctx context.Context, labelSelector labels.Selector) (*security.OrganizationList, error) {
 	organizations := &security.OrganizationList{}
 
@@ -85,12 +126,22 @@ func (r *Resource) getOrganizationsForLabelSelector(ctx context.Context, labelSe
 	return organizations, nil
 }
 
-func (r *Resource) getNamespacesFromOrganizations(ctx context.Context, organizations *security.OrganizationList) ([]string, error) {
+func (r *Resource) getNamespacesFromOrganizations(ctx context.Context, organizations *security.OrganizationList, scopes v1alpha1.RoleBindingTemplateScopes) ([]string, error) {
 	namespaces := []string{}
 
+	clusterSelector, err := getLabelSelectorFromScopeSelector(scopes.ClusterSelector)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
 	for _, o := range organizations.Items {
 		// get the org namespace
-		namespaces = append(namespaces, o.Status.Namespace)
+		if hasTarget(scopes, v1alpha1.ScopeTargetOrganizationNamespace) {
+			namespaces = append(namespaces, o.Status.Namespace)
+		}
+		if !hasTarget(scopes, v1alpha1.ScopeTargetClusterNamespaces) {
+			continue
+		}
 
 		// get the cluster namespaces that belong to the org namespace
 		labelSelector, err := labels.Parse(fmt.Sprintf("%s=%s,%s", label.Organization, o.Name, label.Cluster))
@@ -104,13 +155,16 @@ func (r *Resource) getNamespacesFromOrganizations(ctx context.Context, organizat
 			}
 		}
 		for _, cns := range clusterNamespaces.Items {
+			if !clusterSelector.Matches(labels.Set(cns.Labels)) {
+				continue
+			}
 			namespaces = append(namespaces, cns.Name)
 		}
 	}
 	return namespaces, nil
 }
 
-func (r *Resource) getNamespacesFromScope(ctx context.Context, scopes v1alpha1.RoleBindingTemplateScopes) ([]string, error) {
+func (r *Resource) getNamespacesFromScope(ctx context.Context, scopes v1alpha1.RoleBindingTemplateScopes) ([]corev1.Namespace, error) {
 	labelSelector, err := getLabelSelectorFromScopes(scopes)
 	if err != nil {
 		return nil, microerror.Mask(err)
@@ -121,20 +175,27 @@ func (r *Resource) getNamespacesFromScope(ctx context.Context, scopes v1alpha1.R
 		return nil, microerror.Mask(err)
 	}
 
-	namespaces, err := r.getNamespacesFromOrganizations(ctx, organizations)
+	namespaces, err := r.getNamespacesFromOrganizations(ctx, organizations, scopes)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	namespaceSelector, err := getLabelSelectorFromScopeSelector(scopes.NamespaceSelector)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
-	scope := []string{}
+	scope := []corev1.Namespace{}
 	for _, ns := range namespaces {
 		namespace, err := r.k8sClient.K8sClient().CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
 		if err != nil {
 			continue
 		} else if namespace.DeletionTimestamp != nil {
 			continue
+		} else if !namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+			continue
 		}
-		scope = append(scope, ns)
+		scope = append(scope, *namespace)
 	}
 
 	return scope, nil
@@ -147,3 +208,11 @@ func getRoleBindingNameFromTemplate(template v1alpha1.RoleBindingTemplate) strin
 	}
 	return roleBindingName
 }
+
+func getRoleBindingNameForNamespace(template v1alpha1.RoleBindingTemplate, namespace corev1.Namespace) (string, error) {
+	roleBindingName, err := renderValue(getRoleBindingNameFromTemplate(template), getTemplateVariables(namespace))
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	return roleBindingName, nil
+}
@@ -27,6 +27,9 @@ func TestGetNamespacesFromScope(t *testing.T) {
 		Name                 string
 		MatchLabels          map[string]string
 		MatchExpressions     []metav1.LabelSelectorRequirement
+		NamespaceSelector    v1alpha1.ScopeSelector
+		ClusterSelector      v1alpha1.ScopeSelector
+		Targets              []v1alpha1.ScopeTarget
 		ExistingOrgStructure []int // each number represents number of cluster ns in an org
 
 		expectedNamespaces []string
@@ -119,6 +122,50 @@ func TestGetNamespacesFromScope(t *testing.T) {
 			ExistingOrgStructure: []int{1, 2, 3},
 			expectedNamespaces:   []string{"org-organization-1", "cluster-0-org-1", "cluster-1-org-1"},
 		},
+		{
+			Name: "case10: cluster selector",
+			ClusterSelector: v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"environment": "prod"},
+			},
+			ExistingOrgStructure: []int{1, 2},
+			expectedNamespaces:   []string{"org-organization-0", "cluster-0-org-0", "org-organization-1", "cluster-0-org-1"},
+		},
+		{
+			Name: "case11: namespace selector",
+			NamespaceSelector: v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"environment": "dev"},
+			},
+			ExistingOrgStructure: []int{1, 2},
+			expectedNamespaces:   []string{"cluster-1-org-1"},
+		},
+		{
+			Name:                 "case12: target cluster namespaces only",
+			Targets:              []v1alpha1.ScopeTarget{v1alpha1.ScopeTargetClusterNamespaces},
+			ExistingOrgStructure: []int{1, 2},
+			expectedNamespaces:   []string{"cluster-0-org-0", "cluster-0-org-1", "cluster-1-org-1"},
+		},
+		{
+			Name:                 "case13: target organization namespace only",
+			Targets:              []v1alpha1.ScopeTarget{v1alpha1.ScopeTargetOrganizationNamespace},
+			ExistingOrgStructure: []int{1, 2},
+			expectedNamespaces:   []string{"org-organization-0", "org-organization-1"},
+		},
+		{
+			Name:        "case14: target both namespace kinds, filtered by org and cluster selector",
+			MatchLabels: map[string]string{"key": "value-1"},
+			ClusterSelector: v1alpha1.ScopeSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "environment",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"prod"},
+					},
+				},
+			},
+			Targets:              []v1alpha1.ScopeTarget{v1alpha1.ScopeTargetOrganizationNamespace, v1alpha1.ScopeTargetClusterNamespaces},
+			ExistingOrgStructure: []int{1, 2, 3},
+			expectedNamespaces:   []string{"org-organization-1", "cluster-0-org-1"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -129,6 +176,9 @@ func TestGetNamespacesFromScope(t *testing.T) {
 					MatchLabels:      tc.MatchLabels,
 					MatchExpressions: tc.MatchExpressions,
 				},
+				NamespaceSelector: tc.NamespaceSelector,
+				ClusterSelector:   tc.ClusterSelector,
+				Targets:           tc.Targets,
 			}
 
 			fakeClient, err := getTestClient(tc.ExistingOrgStructure)
@@ -148,8 +198,12 @@ func TestGetNamespacesFromScope(t *testing.T) {
 				t.Fatalf("Expected error, got success")
 			}
 
-			if !reflect.DeepEqual(result, tc.expectedNamespaces) {
-				t.Fatalf("Expected %v to be equal to %v", result, tc.expectedNamespaces)
+			names := []string{}
+			for _, ns := range result {
+				names = append(names, ns.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expectedNamespaces) {
+				t.Fatalf("Expected %v to be equal to %v", names, tc.expectedNamespaces)
 			}
 		})
 	}
@@ -297,6 +351,7 @@ func getTestObjects(orgs []int) ([]runtime.Object, []runtime.Object) {
 					Labels: map[string]string{
 						label.Organization: pkgkey.OrganizationName(orgNamespace.Name),
 						label.Cluster:      fmt.Sprintf("cluster-%v-org-%v", cluster, org),
+						"environment":      []string{"prod", "dev"}[cluster%2],
 					},
 				},
 			}
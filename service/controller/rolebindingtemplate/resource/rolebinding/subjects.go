@@ -0,0 +1,157 @@
+package rolebinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+// sourcedSubjects holds the subjects resolved from the subjectsFrom sources of a template
+// during a single reconciliation.
+type sourcedSubjects struct {
+	// static are the subjects which are the same for all namespaces, e.g. from access groups and config maps
+	static []rbacv1.Subject
+	// annotations are the keys of the organization annotations holding further groups
+	annotations []string
+	// organizations caches the organizations by name, nil if the organization does not exist
+	organizations map[string]*security.Organization
+}
+
+// resolveSubjectsFrom resolves the sources of the template which do not depend on the namespace.
+func (r *Resource) resolveSubjectsFrom(ctx context.Context, template v1alpha1.RoleBindingTemplate) (*sourcedSubjects, error) {
+	sourced := &sourcedSubjects{
+		organizations: map[string]*security.Organization{},
+	}
+
+	for i, source := range template.Spec.Template.SubjectsFrom {
+		switch {
+		case source.AccessGroups != "":
+			groups, err := r.getAccessGroups(source.AccessGroups)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			sourced.static = appendSubjects(sourced.static, accessgroup.GroupsToSubjects(groups))
+		case source.ConfigMapKeyRef != nil:
+			ref := source.ConfigMapKeyRef
+			configMap, err := r.k8sClient.K8sClient().CoreV1().ConfigMaps(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return nil, microerror.Maskf(invalidConfigError, "ConfigMap %s/%s referenced in spec.template.subjectsFrom[%d] does not exist", ref.Namespace, ref.Name, i)
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			value, ok := configMap.Data[ref.Key]
+			if !ok {
+				return nil, microerror.Maskf(invalidConfigError, "ConfigMap %s/%s referenced in spec.template.subjectsFrom[%d] has no key %s", ref.Namespace, ref.Name, i, ref.Key)
+			}
+			sourced.static = appendSubjects(sourced.static, parseGroups(value))
+		case source.OrganizationAnnotation != "":
+			sourced.annotations = append(sourced.annotations, source.OrganizationAnnotation)
+		}
+	}
+
+	return sourced, nil
+}
+
+// addSourcedSubjects adds the sourced subjects for the namespace to the role binding
+func (r *Resource) addSourcedSubjects(ctx context.Context, sourced *sourcedSubjects, namespace corev1.Namespace, roleBinding *rbacv1.RoleBinding) error {
+	roleBinding.Subjects = appendSubjects(roleBinding.Subjects, sourced.static)
+	if len(sourced.annotations) == 0 {
+		return nil
+	}
+
+	name := getTemplateVariables(namespace).Organization
+	if name == "" {
+		return nil
+	}
+	organization, ok := sourced.organizations[name]
+	if !ok {
+		organization = &security.Organization{}
+		err := r.k8sClient.CtrlClient().Get(ctx, client.ObjectKey{Name: name}, organization)
+		if apierrors.IsNotFound(err) {
+			organization = nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		sourced.organizations[name] = organization
+	}
+	if organization == nil {
+		return nil
+	}
+
+	for _, key := range sourced.annotations {
+		roleBinding.Subjects = appendSubjects(roleBinding.Subjects, parseGroups(organization.Annotations[key]))
+	}
+
+	return nil
+}
+
+func (r *Resource) getAccessGroups(role v1alpha1.AccessGroupRole) ([]accessgroup.AccessGroup, error) {
+	accessGroups := r.accessGroups.Get()
+	switch role {
+	case v1alpha1.AccessGroupRoleCustomerAdmins:
+		return accessGroups.WriteAllCustomerGroups, nil
+	case v1alpha1.AccessGroupRoleCustomerReaders:
+		return accessGroups.ReadAllCustomerGroups, nil
+	case v1alpha1.AccessGroupRoleGiantswarmAdmins:
+		return accessGroups.WriteAllGiantswarmGroups, nil
+	default:
+		return nil, microerror.Maskf(invalidConfigError, "unknown access group role %#q", role)
+	}
+}
+
+// parseGroups returns group subjects for a list of group names separated by newlines or commas
+func parseGroups(value string) []rbacv1.Subject {
+	return accessgroup.GroupsToSubjects(accessgroup.ParseGroups(value))
+}
+
+func appendSubjects(subjects []rbacv1.Subject, additional []rbacv1.Subject) []rbacv1.Subject {
+	for _, subject := range additional {
+		if !containsSubject(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
+
+// validateSubjectsSource returns a message if not exactly one source is set or a reference is incomplete
+func validateSubjectsSource(source v1alpha1.SubjectsSource) string {
+	set := 0
+	if source.AccessGroups != "" {
+		set++
+	}
+	if source.ConfigMapKeyRef != nil {
+		set++
+		ref := source.ConfigMapKeyRef
+		if ref.Namespace == "" || ref.Name == "" || ref.Key == "" {
+			return "configMapKeyRef must have a namespace, name and key"
+		}
+	}
+	if source.OrganizationAnnotation != "" {
+		set++
+	}
+	if set != 1 {
+		return fmt.Sprintf("must set exactly one of accessGroups, configMapKeyRef or organizationAnnotation, got %d", set)
+	}
+	return ""
+}
+
+// ReferencesConfigMap reports whether one of the subjectsFrom sources of the template is the given config map
+func ReferencesConfigMap(template v1alpha1.RoleBindingTemplate, namespace, name string) bool {
+	for _, source := range template.Spec.Template.SubjectsFrom {
+		ref := source.ConfigMapKeyRef
+		if ref != nil && ref.Namespace == namespace && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
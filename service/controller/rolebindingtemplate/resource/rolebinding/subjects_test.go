@@ -0,0 +1,179 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+func TestEnsureCreatedSubjectsFrom(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Subjects     []rbacv1.Subject
+		SubjectsFrom []v1alpha1.SubjectsSource
+
+		expectedSubjects []string
+		expectedReason   string
+		expectError      bool
+	}{
+		{
+			Name: "case0: subjects from access groups",
+			SubjectsFrom: []v1alpha1.SubjectsSource{
+				{AccessGroups: v1alpha1.AccessGroupRoleCustomerAdmins},
+				{AccessGroups: v1alpha1.AccessGroupRoleCustomerReaders},
+			},
+			expectedSubjects: []string{"customer:admins", "customer:readers"},
+		},
+		{
+			Name: "case1: subjects from a config map key are added to the inline subjects",
+			Subjects: []rbacv1.Subject{
+				{Kind: "Group", Name: "inline"},
+			},
+			SubjectsFrom: []v1alpha1.SubjectsSource{
+				{ConfigMapKeyRef: &v1alpha1.ConfigMapKeyReference{Namespace: "giantswarm", Name: "groups", Key: "developers"}},
+			},
+			expectedSubjects: []string{"inline", "dev-a", "dev-b", "dev-c"},
+		},
+		{
+			Name: "case2: subjects from an annotation of the organization",
+			SubjectsFrom: []v1alpha1.SubjectsSource{
+				{OrganizationAnnotation: "example.com/groups"},
+				{OrganizationAnnotation: "example.com/missing"},
+			},
+			expectedSubjects: []string{"org-admins", "org-readers"},
+		},
+		{
+			Name: "case3: duplicate subjects are bound once",
+			Subjects: []rbacv1.Subject{
+				{Kind: "Group", Name: "customer:admins"},
+			},
+			SubjectsFrom: []v1alpha1.SubjectsSource{
+				{AccessGroups: v1alpha1.AccessGroupRoleCustomerAdmins},
+			},
+			expectedSubjects: []string{"customer:admins"},
+		},
+		{
+			Name: "case4: missing config map key fails the template",
+			SubjectsFrom: []v1alpha1.SubjectsSource{
+				{ConfigMapKeyRef: &v1alpha1.ConfigMapKeyReference{Namespace: "giantswarm", Name: "groups", Key: "missing"}},
+			},
+			expectedReason: "SubjectsFromFailed",
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sourced",
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef:      rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+						Subjects:     tc.Subjects,
+						SubjectsFrom: tc.SubjectsFrom,
+					},
+					Scopes: v1alpha1.RoleBindingTemplateScopes{
+						OrganizationSelector: v1alpha1.ScopeSelector{
+							MatchLabels: map[string]string{"name": "example"},
+						},
+					},
+				},
+			}
+
+			organization := getTestOrganization("example")
+			organization.Annotations = map[string]string{
+				"example.com/groups": "org-admins, org-readers",
+			}
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(template, organization).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-example"}},
+						&corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{Name: "groups", Namespace: "giantswarm"},
+							Data: map[string]string{
+								"developers": "dev-a\ndev-b, dev-c\n",
+							},
+						},
+					),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+				AccessGroups: accessgroup.NewStore(accessgroup.AccessGroups{
+					WriteAllCustomerGroups: []accessgroup.AccessGroup{{Name: "customer:admins"}},
+					ReadAllCustomerGroups:  []accessgroup.AccessGroup{{Name: "customer:readers"}},
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, template)
+			if tc.expectError {
+				if !IsInvalidConfig(err) {
+					t.Fatalf("Expected invalid config error, got %v", err)
+				}
+				result := &v1alpha1.RoleBindingTemplate{}
+				err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+				if err != nil {
+					t.Fatalf("failed to get template: %s", err)
+				}
+				ready := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeReady)
+				if ready == nil || ready.Reason != tc.expectedReason {
+					t.Fatalf("Expected Ready condition with reason %s, got %v", tc.expectedReason, ready)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			roleBinding, err := k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "sourced", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected role binding, got error %v", err)
+			}
+			var subjects []string
+			for _, subject := range roleBinding.Subjects {
+				subjects = append(subjects, subject.Name)
+			}
+			if !reflect.DeepEqual(tc.expectedSubjects, subjects) {
+				t.Fatalf("Expected subjects %v, got %v", tc.expectedSubjects, subjects)
+			}
+		})
+	}
+}
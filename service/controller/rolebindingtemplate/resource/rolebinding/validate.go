@@ -0,0 +1,153 @@
+package rolebinding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+)
+
+// ValidateTemplate checks a roleBindingTemplate for errors which would keep the role
+// bindings from being rendered or applied. The returned warnings list the subjects
+// which are not bound in the namespaces protected by the policy.
+func ValidateTemplate(template v1alpha1.RoleBindingTemplate, policy protection.Policy) ([]string, error) {
+	var errs []string
+
+	roleRef := template.Spec.Template.RoleRef
+	if incompleteRoleRef(roleRef) {
+		errs = append(errs, fmt.Sprintf("spec.template.roleRef must reference a Role or ClusterRole by name, got kind %#q and name %#q", roleRef.Kind, roleRef.Name))
+	}
+	if roleRef.APIGroup != "" && roleRef.APIGroup != rbacv1.GroupName {
+		errs = append(errs, fmt.Sprintf("spec.template.roleRef.apiGroup must be %#q, got %#q", rbacv1.GroupName, roleRef.APIGroup))
+	}
+
+	if _, err := getLabelSelectorFromScopes(template.Spec.Scopes); err != nil {
+		errs = append(errs, fmt.Sprintf("spec.scopes.organizationSelector is invalid: %v", err))
+	}
+	if _, err := getLabelSelectorFromScopeSelector(template.Spec.Scopes.NamespaceSelector); err != nil {
+		errs = append(errs, fmt.Sprintf("spec.scopes.namespaceSelector is invalid: %v", err))
+	}
+	if _, err := getLabelSelectorFromScopeSelector(template.Spec.Scopes.ClusterSelector); err != nil {
+		errs = append(errs, fmt.Sprintf("spec.scopes.clusterSelector is invalid: %v", err))
+	}
+
+	if template.Spec.ValidFrom != nil && template.Spec.ValidUntil != nil && !template.Spec.ValidFrom.Before(template.Spec.ValidUntil) {
+		errs = append(errs, "spec.validUntil must be after spec.validFrom")
+	}
+
+	for i, subject := range template.Spec.Template.Subjects {
+		if msg := validateSubject(subject); msg != "" {
+			errs = append(errs, fmt.Sprintf("spec.template.subjects[%d] %s", i, msg))
+		}
+	}
+
+	for i, source := range template.Spec.Template.SubjectsFrom {
+		if msg := validateSubjectsSource(source); msg != "" {
+			errs = append(errs, fmt.Sprintf("spec.template.subjectsFrom[%d] %s", i, msg))
+		}
+	}
+
+	// render the template for sample namespaces to find invalid placeholders and names
+	if len(errs) == 0 {
+		for _, namespace := range getSampleNamespaces() {
+			roleBinding, err := getRoleBindingFromTemplate(template, namespace)
+			if err != nil {
+				errs = append(errs, err.Error())
+				break
+			}
+			msgs := path.IsValidPathSegmentName(roleBinding.Name)
+			if roleBinding.Name == "" {
+				msgs = append(msgs, "must not be empty")
+			}
+			if len(msgs) > 0 {
+				errs = append(errs, fmt.Sprintf("role binding name %#q rendered for namespace %s is invalid: %s", roleBinding.Name, namespace.Name, strings.Join(msgs, ", ")))
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, microerror.Maskf(invalidConfigError, "RoleBindingTemplate %s is invalid: %s", template.Name, strings.Join(errs, "; "))
+	}
+
+	var warnings []string
+	for _, protectedNamespace := range policy.Namespaces {
+		roleBinding, err := getRoleBindingFromTemplate(template, getProtectedSampleNamespace(protectedNamespace.Name))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		for _, subject := range roleBinding.Subjects {
+			if !containsSubject(cleanSubjects(policy, roleBinding.DeepCopy(), protectedNamespace.Name).Subjects, subject) {
+				warnings = append(warnings, fmt.Sprintf("subject %s %#q is not bound in protected namespace %s", subject.Kind, subject.Name, protectedNamespace.Name))
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+func validateSubject(subject rbacv1.Subject) string {
+	if subject.Name == "" {
+		return "must have a name"
+	}
+	switch subject.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind:
+		if subject.APIGroup != "" && subject.APIGroup != rbacv1.GroupName {
+			return fmt.Sprintf("of kind %s must have apiGroup %#q, got %#q", subject.Kind, rbacv1.GroupName, subject.APIGroup)
+		}
+	case rbacv1.ServiceAccountKind:
+		if subject.APIGroup != "" {
+			return fmt.Sprintf("of kind %s must not have an apiGroup, got %#q", subject.Kind, subject.APIGroup)
+		}
+	default:
+		return fmt.Sprintf("has unsupported kind %#q, must be one of %s, %s or %s", subject.Kind, rbacv1.UserKind, rbacv1.GroupKind, rbacv1.ServiceAccountKind)
+	}
+	return ""
+}
+
+func getSampleNamespaces() []corev1.Namespace {
+	return []corev1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "org-example",
+				Labels: map[string]string{
+					label.Organization: "example",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "abc12",
+				Labels: map[string]string{
+					label.Organization: "example",
+					label.Cluster:      "abc12",
+				},
+			},
+		},
+	}
+}
+
+func getProtectedSampleNamespace(name string) corev1.Namespace {
+	return corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func containsSubject(subjects []rbacv1.Subject, subject rbacv1.Subject) bool {
+	for _, s := range subjects {
+		if s == subject {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package rolebinding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Template v1alpha1.RoleBindingTemplateResource
+		Scopes   v1alpha1.RoleBindingTemplateScopes
+		Validity [2]*metav1.Time
+
+		expectedWarnings []string
+		expectedError    string
+	}{
+		{
+			Name: "case0: valid template with service account subject",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "test-SA", Namespace: pkgkey.FluxNamespaceName},
+				},
+			},
+		},
+		{
+			Name: "case1: warn about subjects removed in protected namespaces",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "Role"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group", APIGroup: rbacv1.GroupName},
+					{Kind: "ServiceAccount", Name: "test-SA", Namespace: "org-example"},
+					{Kind: "ServiceAccount", Name: "automation"},
+				},
+			},
+			expectedWarnings: []string{
+				"subject Group `test-group` is not bound in protected namespace org-giantswarm",
+				"subject ServiceAccount `test-SA` is not bound in protected namespace org-giantswarm",
+			},
+		},
+		{
+			Name: "case2: incomplete roleRef",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "AnotherKind"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedError: "spec.template.roleRef must reference a Role or ClusterRole by name",
+		},
+		{
+			Name: "case3: invalid selector",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "key", Operator: "Unknown"},
+					},
+				},
+			},
+			expectedError: "spec.scopes.organizationSelector is invalid",
+		},
+		{
+			Name: "case4: unsupported subject kind",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Team", Name: "test-team"},
+				},
+			},
+			expectedError: "spec.template.subjects[0] has unsupported kind `Team`",
+		},
+		{
+			Name: "case5: service account with apiGroup",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "test-SA", APIGroup: rbacv1.GroupName},
+				},
+			},
+			expectedError: "spec.template.subjects[0] of kind ServiceAccount must not have an apiGroup",
+		},
+		{
+			Name: "case6: invalid binding name",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "{{ .Organization }}/admins",
+				},
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "test-group"},
+				},
+			},
+			expectedError: "role binding name `example/admins` rendered for namespace org-example is invalid",
+		},
+		{
+			Name: "case7: invalid placeholder",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "customer:{{ .Org }}:admins"},
+				},
+			},
+			expectedError: "invalid placeholder in `customer:{{ .Org }}:admins`",
+		},
+		{
+			Name: "case8: validity window ends before it starts",
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+				Subjects: []rbacv1.Subject{
+					{Kind: "ServiceAccount", Name: "automation"},
+				},
+			},
+			Validity: [2]*metav1.Time{
+				{Time: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedError: "spec.validUntil must be after spec.validFrom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "something",
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: tc.Template,
+					Scopes:   tc.Scopes,
+
+					ValidFrom:  tc.Validity[0],
+					ValidUntil: tc.Validity[1],
+				},
+			}
+
+			warnings, err := ValidateTemplate(template, protection.DefaultPolicy())
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if tc.expectedError != "" {
+				if !IsInvalidConfig(err) {
+					t.Fatalf("Expected invalid config error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("Expected error to contain %q, got %q", tc.expectedError, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(tc.expectedWarnings, warnings) {
+				t.Fatalf("Expected warnings %v, got %v", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
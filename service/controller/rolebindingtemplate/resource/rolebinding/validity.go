@@ -0,0 +1,71 @@
+package rolebinding
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+)
+
+// validity describes where the current time lies relative to the validity window of a template
+type validity struct {
+	active bool
+	reason string
+	// next is the next time the validity changes, nil if it does not change anymore
+	next *time.Time
+}
+
+func getValidity(spec v1alpha1.RoleBindingTemplateSpec, now time.Time) validity {
+	if spec.ValidFrom != nil && now.Before(spec.ValidFrom.Time) {
+		next := spec.ValidFrom.Time
+		return validity{active: false, reason: "NotYetValid", next: &next}
+	}
+	if spec.ValidUntil != nil && !now.Before(spec.ValidUntil.Time) {
+		return validity{active: false, reason: "Expired"}
+	}
+	if spec.ValidUntil != nil {
+		next := spec.ValidUntil.Time
+		return validity{active: true, reason: "WithinValidity", next: &next}
+	}
+	return validity{active: true, reason: "WithinValidity"}
+}
+
+func setActiveCondition(template *v1alpha1.RoleBindingTemplate, v validity) {
+	status := metav1.ConditionFalse
+	message := "RoleBindings are removed"
+	switch v.reason {
+	case "NotYetValid":
+		message = fmt.Sprintf("RoleBindings are created at %s", template.Spec.ValidFrom.UTC().Format(time.RFC3339))
+	case "Expired":
+		message = fmt.Sprintf("RoleBindings were removed at %s", template.Spec.ValidUntil.UTC().Format(time.RFC3339))
+	case "WithinValidity":
+		status = metav1.ConditionTrue
+		message = "RoleBindings are applied"
+		if template.Spec.ValidUntil != nil {
+			message = fmt.Sprintf("RoleBindings are applied until %s", template.Spec.ValidUntil.UTC().Format(time.RFC3339))
+		}
+	}
+	setCondition(template, v1alpha1.ConditionTypeActive, status, v.reason, message)
+}
+
+// requeueAt requeues the template at the given time, replacing the time
+// scheduled before, as the controller would otherwise only pick up changes of
+// the validity with the next resync.
+func (r *Resource) requeueAt(template v1alpha1.RoleBindingTemplate, at time.Time) {
+	if r.scheduler == nil {
+		return
+	}
+
+	r.scheduler.Schedule(template.Name, at)
+}
+
+// cancelRequeue drops the time scheduled for the template, if any
+func (r *Resource) cancelRequeue(template v1alpha1.RoleBindingTemplate) {
+	if r.scheduler == nil {
+		return
+	}
+
+	r.scheduler.Cancel(template.Name)
+}
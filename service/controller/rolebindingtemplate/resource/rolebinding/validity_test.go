@@ -0,0 +1,240 @@
+package rolebinding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+)
+
+func TestGetValidity(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+
+	testCases := []struct {
+		Name       string
+		ValidFrom  *metav1.Time
+		ValidUntil *metav1.Time
+
+		expectedValidity validity
+	}{
+		{
+			Name:             "case0: no validity window",
+			expectedValidity: validity{active: true, reason: "WithinValidity"},
+		},
+		{
+			Name:             "case1: not yet valid",
+			ValidFrom:        &after,
+			expectedValidity: validity{active: false, reason: "NotYetValid", next: &after.Time},
+		},
+		{
+			Name:             "case2: within validity window",
+			ValidFrom:        &before,
+			ValidUntil:       &after,
+			expectedValidity: validity{active: true, reason: "WithinValidity", next: &after.Time},
+		},
+		{
+			Name:             "case3: expired",
+			ValidUntil:       &before,
+			expectedValidity: validity{active: false, reason: "Expired"},
+		},
+		{
+			Name:             "case4: valid from now on",
+			ValidFrom:        &metav1.Time{Time: now},
+			expectedValidity: validity{active: true, reason: "WithinValidity"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			spec := v1alpha1.RoleBindingTemplateSpec{
+				ValidFrom:  tc.ValidFrom,
+				ValidUntil: tc.ValidUntil,
+			}
+
+			result := getValidity(spec, now)
+			if !reflect.DeepEqual(tc.expectedValidity, result) {
+				t.Fatalf("Expected validity %v, got %v", tc.expectedValidity, result)
+			}
+		})
+	}
+}
+
+func TestEnsureCreatedValidity(t *testing.T) {
+	before := metav1.NewTime(time.Now().Add(-time.Hour))
+	after := metav1.NewTime(time.Now().Add(time.Hour))
+
+	testCases := []struct {
+		Name       string
+		ValidFrom  *metav1.Time
+		ValidUntil *metav1.Time
+
+		expectedNamespaces   []string
+		expectedRoleBinding  bool
+		expectedActive       metav1.ConditionStatus
+		expectedActiveReason string
+		expectedRequeue      bool
+	}{
+		{
+			Name:                 "case0: apply role binding within validity window",
+			ValidFrom:            &before,
+			ValidUntil:           &after,
+			expectedNamespaces:   []string{"org-example"},
+			expectedRoleBinding:  true,
+			expectedActive:       metav1.ConditionTrue,
+			expectedActiveReason: "WithinValidity",
+			expectedRequeue:      true,
+		},
+		{
+			Name:                 "case1: remove role binding once expired",
+			ValidUntil:           &before,
+			expectedActive:       metav1.ConditionFalse,
+			expectedActiveReason: "Expired",
+		},
+		{
+			Name:                 "case2: remove role binding until valid",
+			ValidFrom:            &after,
+			expectedActive:       metav1.ConditionFalse,
+			expectedActiveReason: "NotYetValid",
+			expectedRequeue:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := &v1alpha1.RoleBindingTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "something",
+				},
+				Spec: v1alpha1.RoleBindingTemplateSpec{
+					Template: v1alpha1.RoleBindingTemplateResource{
+						RoleRef: rbacv1.RoleRef{Name: "example", Kind: "ClusterRole"},
+						Subjects: []rbacv1.Subject{
+							{Kind: "Group", Name: "test-group"},
+						},
+					},
+					ValidFrom:  tc.ValidFrom,
+					ValidUntil: tc.ValidUntil,
+				},
+				Status: v1alpha1.RoleBindingTemplateStatus{
+					Namespaces: []string{"org-example"},
+				},
+			}
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(template, getTestOrganization("example")).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(
+						&corev1.Namespace{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "org-example",
+							},
+						},
+						&rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "something",
+								Namespace: "org-example",
+								Labels: map[string]string{
+									label.ManagedBy: project.Name(),
+								},
+							},
+						},
+					),
+				})
+			}
+
+			scheduler, err := resync.NewScheduler(resync.SchedulerConfig{
+				CtrlClient: k8sClientFake.CtrlClient(),
+				Logger:     microloggertest.New(),
+				NewObjectFunc: func() client.Object {
+					return new(v1alpha1.RoleBindingTemplate)
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+				Scheduler: scheduler,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, template)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			defer r.cancelRequeue(*template)
+
+			_, err = k8sClientFake.K8sClient().RbacV1().RoleBindings("org-example").Get(ctx, "something", metav1.GetOptions{})
+			if tc.expectedRoleBinding && err != nil {
+				t.Fatalf("Expected role binding, got error %v", err)
+			}
+			if !tc.expectedRoleBinding && !apierrors.IsNotFound(err) {
+				t.Fatalf("Expected role binding to be removed, got error %v", err)
+			}
+
+			result := &v1alpha1.RoleBindingTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: template.Name}, result)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expectedNamespaces, result.Status.Namespaces) {
+				t.Fatalf("Expected namespaces %v, got %v", tc.expectedNamespaces, result.Status.Namespaces)
+			}
+			active := meta.FindStatusCondition(result.Status.Conditions, v1alpha1.ConditionTypeActive)
+			if active == nil || active.Status != tc.expectedActive || active.Reason != tc.expectedActiveReason {
+				t.Fatalf("Expected Active condition %s with reason %s, got %v", tc.expectedActive, tc.expectedActiveReason, active)
+			}
+			_, requeued := scheduler.Scheduled(template.Name)
+			if requeued != tc.expectedRequeue {
+				t.Fatalf("Expected requeue %t, got %t", tc.expectedRequeue, requeued)
+			}
+
+			// deleting the template drops the requeue
+			err = r.EnsureDeleted(ctx, result)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if _, requeued = scheduler.Scheduled(template.Name); requeued {
+				t.Fatalf("Expected requeue to be cancelled on delete")
+			}
+		})
+	}
+}
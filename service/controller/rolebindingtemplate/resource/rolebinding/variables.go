@@ -0,0 +1,61 @@
+package rolebinding
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+)
+
+const (
+	namespaceKindOrganization = "organization"
+	namespaceKindCluster      = "cluster"
+)
+
+// templateVariables holds the values which can be referenced by placeholders like
+// {{ .Organization }} in the subject names, name, labels and annotations of a template.
+type templateVariables struct {
+	Organization  string
+	Namespace     string
+	ClusterName   string
+	NamespaceKind string
+}
+
+func getTemplateVariables(namespace corev1.Namespace) templateVariables {
+	variables := templateVariables{
+		Organization:  pkgkey.Organization(&namespace),
+		Namespace:     namespace.Name,
+		NamespaceKind: namespaceKindOrganization,
+	}
+	if variables.Organization == "" && pkgkey.IsOrgNamespace(namespace.Name) {
+		variables.Organization = pkgkey.OrganizationName(namespace.Name)
+	}
+	if cluster, ok := namespace.Labels[label.Cluster]; ok {
+		variables.ClusterName = cluster
+		variables.NamespaceKind = namespaceKindCluster
+	}
+	return variables
+}
+
+// renderValue replaces the placeholders in the value, values without placeholders are returned as is.
+func renderValue(value string, variables templateVariables) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	t, err := template.New("value").Parse(value)
+	if err != nil {
+		return "", microerror.Maskf(invalidConfigError, "invalid placeholder in %#q: %v", value, err)
+	}
+
+	var rendered strings.Builder
+	if err := t.Execute(&rendered, variables); err != nil {
+		return "", microerror.Maskf(invalidConfigError, "invalid placeholder in %#q: %v", value, err)
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,118 @@
+package rolebinding
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/rbac-operator/service/test"
+)
+
+func TestGetTemplateVariables(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Namespace *corev1.Namespace
+
+		expectedVariables templateVariables
+	}{
+		{
+			Name:      "case0: organization namespace",
+			Namespace: test.NewOrgNamespace("example"),
+
+			expectedVariables: templateVariables{
+				Organization:  "example",
+				Namespace:     "org-example",
+				NamespaceKind: "organization",
+			},
+		},
+		{
+			Name:      "case1: organization namespace without organization label",
+			Namespace: test.NewGenericNamespace("org-example"),
+
+			expectedVariables: templateVariables{
+				Organization:  "example",
+				Namespace:     "org-example",
+				NamespaceKind: "organization",
+			},
+		},
+		{
+			Name:      "case2: cluster namespace",
+			Namespace: test.NewClusterNamespace("abc12", "example"),
+
+			expectedVariables: templateVariables{
+				Organization:  "example",
+				Namespace:     "abc12",
+				ClusterName:   "abc12",
+				NamespaceKind: "cluster",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := getTemplateVariables(*tc.Namespace)
+			if !reflect.DeepEqual(tc.expectedVariables, result) {
+				t.Fatalf("Expected %v to be equal to %v", result, tc.expectedVariables)
+			}
+		})
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	variables := templateVariables{
+		Organization:  "example",
+		Namespace:     "abc12",
+		ClusterName:   "abc12",
+		NamespaceKind: "cluster",
+	}
+
+	testCases := []struct {
+		Name  string
+		Value string
+
+		expectedValue string
+		expectError   bool
+	}{
+		{
+			Name:          "case0: no placeholders",
+			Value:         "customer:admins",
+			expectedValue: "customer:admins",
+		},
+		{
+			Name:          "case1: organization placeholder",
+			Value:         "customer:{{ .Organization }}:admins",
+			expectedValue: "customer:example:admins",
+		},
+		{
+			Name:          "case2: multiple placeholders",
+			Value:         "{{ .NamespaceKind }}-{{ .ClusterName }}-{{ .Namespace }}",
+			expectedValue: "cluster-abc12-abc12",
+		},
+		{
+			Name:        "case3: unknown placeholder",
+			Value:       "{{ .Unknown }}",
+			expectError: true,
+		},
+		{
+			Name:        "case4: invalid placeholder",
+			Value:       "{{ .Organization",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := renderValue(tc.Value, variables)
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if tc.expectError && !IsInvalidConfig(err) {
+				t.Fatalf("Expected invalid config error, got %v", err)
+			}
+			if result != tc.expectedValue {
+				t.Fatalf("Expected %v to be equal to %v", result, tc.expectedValue)
+			}
+		})
+	}
+}
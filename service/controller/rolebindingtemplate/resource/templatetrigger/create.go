@@ -0,0 +1,42 @@
+package templatetrigger
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OnChange requeues the templates affected by the change of the given
+// organization, namespace or config map. Deleted objects requeue the templates
+// as well, so that their status no longer lists namespaces which are gone.
+func (r *Resource) OnChange(ctx context.Context, obj client.Object) error {
+	switch o := obj.(type) {
+	case *security.Organization:
+		var namespaces []string
+		if o.Status.Namespace != "" {
+			namespaces = append(namespaces, o.Status.Namespace)
+		}
+		if err := r.requeueTemplates(ctx, o, namespaces); err != nil {
+			return microerror.Mask(err)
+		}
+	case *corev1.Namespace:
+		organization, err := r.getOrganizationForNamespace(ctx, *o)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		if err := r.requeueTemplates(ctx, organization, []string{o.Name}); err != nil {
+			return microerror.Mask(err)
+		}
+	case *corev1.ConfigMap:
+		if err := r.requeueTemplatesForConfigMap(ctx, o); err != nil {
+			return microerror.Mask(err)
+		}
+	default:
+		return microerror.Maskf(wrongTypeError, "expected '%T', '%T' or '%T', got '%T'", &security.Organization{}, &corev1.Namespace{}, &corev1.ConfigMap{}, obj)
+	}
+
+	return nil
+}
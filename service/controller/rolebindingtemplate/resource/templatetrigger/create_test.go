@@ -0,0 +1,234 @@
+package templatetrigger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
+	"github.com/giantswarm/rbac-operator/service/test"
+)
+
+func TestOnChange(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		Object             func() client.Object
+		Templates          []*v1alpha1.RoleBindingTemplate
+		OrganizationLabels map[string]string
+
+		expectedRequeued []string
+		expectError      bool
+	}{
+		{
+			Name: "case0: new organization requeues matching templates only",
+			Object: func() client.Object {
+				return newOrganization(map[string]string{"stage": "prod"})
+			},
+			Templates: []*v1alpha1.RoleBindingTemplate{
+				newTemplate("prod-access", map[string]string{"stage": "prod"}, nil),
+				newTemplate("dev-access", map[string]string{"stage": "dev"}, nil),
+			},
+			OrganizationLabels: map[string]string{"stage": "prod"},
+
+			expectedRequeued: []string{"prod-access"},
+		},
+		{
+			Name: "case1: new cluster namespace requeues templates matching its organization",
+			Object: func() client.Object {
+				return test.NewClusterNamespace("abc12", "example")
+			},
+			Templates: []*v1alpha1.RoleBindingTemplate{
+				newTemplate("prod-access", map[string]string{"stage": "prod"}, nil),
+				newTemplate("dev-access", map[string]string{"stage": "dev"}, nil),
+			},
+			OrganizationLabels: map[string]string{"stage": "prod"},
+
+			expectedRequeued: []string{"prod-access"},
+		},
+		{
+			Name: "case2: organization leaving the scope of a template requeues it for clean up",
+			Object: func() client.Object {
+				return newOrganization(map[string]string{"stage": "dev"})
+			},
+			Templates: []*v1alpha1.RoleBindingTemplate{
+				newTemplate("prod-access", map[string]string{"stage": "prod"}, []string{"org-example", "abc12"}),
+				newTemplate("staging-access", map[string]string{"stage": "staging"}, nil),
+			},
+			OrganizationLabels: map[string]string{"stage": "dev"},
+
+			expectedRequeued: []string{"prod-access"},
+		},
+		{
+			Name: "case3: namespace without organization does not requeue templates",
+			Object: func() client.Object {
+				return test.NewClusterNamespace("xyz99", "unknown")
+			},
+			Templates: []*v1alpha1.RoleBindingTemplate{
+				newTemplate("all-access", nil, nil),
+			},
+			OrganizationLabels: map[string]string{"stage": "prod"},
+
+			expectedRequeued: []string{},
+		},
+		{
+			Name: "case4: config map requeues templates sourcing subjects from it",
+			Object: func() client.Object {
+				return newConfigMap()
+			},
+			Templates: []*v1alpha1.RoleBindingTemplate{
+				newTemplateWithConfigMap("sourced-access", map[string]string{"stage": "prod"}),
+				newTemplate("prod-access", map[string]string{"stage": "prod"}, nil),
+			},
+			OrganizationLabels: map[string]string{"stage": "prod"},
+
+			expectedRequeued: []string{"sourced-access"},
+		},
+		{
+			Name: "case5: unexpected object",
+			Object: func() client.Object {
+				return &rbacv1.Role{}
+			},
+
+			expectedRequeued: []string{},
+			expectError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			objects := []runtime.Object{newOrganization(tc.OrganizationLabels)}
+			for _, template := range tc.Templates {
+				objects = append(objects, template)
+			}
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(objects...).
+						WithStatusSubresource(&v1alpha1.RoleBindingTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			err = r.OnChange(ctx, tc.Object())
+			if !tc.expectError && err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+			if tc.expectError && !IsWrongType(err) {
+				t.Fatalf("Expected wrong type error, got %v", err)
+			}
+
+			templates := &v1alpha1.RoleBindingTemplateList{}
+			err = k8sClientFake.CtrlClient().List(ctx, templates)
+			if err != nil {
+				t.Fatalf("failed to get templates: %s", err)
+			}
+			result := []string{}
+			for _, template := range templates.Items {
+				if _, ok := template.Annotations[annotation.Resync]; ok {
+					result = append(result, template.Name)
+				}
+			}
+			if !reflect.DeepEqual(result, tc.expectedRequeued) {
+				t.Fatalf("Expected requeued templates %v, got %v", tc.expectedRequeued, result)
+			}
+		})
+	}
+}
+
+func newOrganization(labels map[string]string) *security.Organization {
+	organization := test.NewOrganization("example")
+	organization.Labels = labels
+	return organization
+}
+
+func newTemplate(name string, matchLabels map[string]string, namespaces []string) *v1alpha1.RoleBindingTemplate {
+	return &v1alpha1.RoleBindingTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1alpha1.RoleBindingTemplateSpec{
+			Template: v1alpha1.RoleBindingTemplateResource{
+				RoleRef: rbacv1.RoleRef{
+					Name: "read-all",
+					Kind: "ClusterRole",
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "on-call"},
+				},
+			},
+			Scopes: v1alpha1.RoleBindingTemplateScopes{
+				OrganizationSelector: v1alpha1.ScopeSelector{
+					MatchLabels: matchLabels,
+				},
+			},
+		},
+		Status: v1alpha1.RoleBindingTemplateStatus{
+			Namespaces: namespaces,
+		},
+	}
+}
+
+func newTemplateWithConfigMap(name string, matchLabels map[string]string) *v1alpha1.RoleBindingTemplate {
+	template := newTemplate(name, matchLabels, nil)
+	template.Spec.Template.Subjects = nil
+	template.Spec.Template.SubjectsFrom = []v1alpha1.SubjectsSource{
+		{
+			ConfigMapKeyRef: &v1alpha1.ConfigMapKeyReference{
+				Namespace: "giantswarm",
+				Name:      "groups",
+				Key:       "on-call",
+			},
+		},
+	}
+	return template
+}
+
+func newConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "groups",
+			Namespace: "giantswarm",
+			Labels: map[string]string{
+				pkglabel.SubjectsSource: "true",
+			},
+		},
+		Data: map[string]string{
+			"on-call": "on-call",
+		},
+	}
+}
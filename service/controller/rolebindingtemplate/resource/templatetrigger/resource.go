@@ -0,0 +1,161 @@
+// templatetrigger package requeues roleBindingTemplates when the organizations or
+// namespaces they are scoped to, or the config maps they source subjects from change,
+// so that new organizations and clusters get their role bindings without waiting for
+// the next resync of the templates. The templates are reconciled by their controller.
+package templatetrigger
+
+import (
+	"context"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/resource/rolebinding"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+}
+
+type Resource struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+}
+
+func New(config Config) (*Resource, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	r := &Resource{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+	}
+
+	return r, nil
+}
+
+// requeueTemplates requeues every roleBindingTemplate whose organization selector matches
+// the given organization, or which is currently applied to one of the given namespaces.
+func (r *Resource) requeueTemplates(ctx context.Context, organization *security.Organization, namespaces []string) error {
+	templates := &v1alpha1.RoleBindingTemplateList{}
+	if err := r.k8sClient.CtrlClient().List(ctx, templates); err != nil {
+		return microerror.Mask(err)
+	}
+
+	var requeue []client.Object
+	for i := range templates.Items {
+		template := &templates.Items[i]
+		if template.DeletionTimestamp != nil {
+			continue
+		}
+
+		inScope, err := matchesOrganization(*template, organization)
+		if err != nil {
+			r.logger.Debugf(ctx, "Could not match roleBindingTemplate %s due to error %v", template.Name, err)
+			continue
+		}
+		if !inScope && !appliedToAny(*template, namespaces) {
+			continue
+		}
+
+		r.logger.Debugf(ctx, "Requeueing roleBindingTemplate %s", template.Name)
+		requeue = append(requeue, template)
+	}
+
+	if err := resync.Enqueue(ctx, r.k8sClient.CtrlClient(), requeue...); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// requeueTemplatesForConfigMap requeues every roleBindingTemplate sourcing subjects from the config map.
+func (r *Resource) requeueTemplatesForConfigMap(ctx context.Context, configMap *corev1.ConfigMap) error {
+	templates := &v1alpha1.RoleBindingTemplateList{}
+	if err := r.k8sClient.CtrlClient().List(ctx, templates); err != nil {
+		return microerror.Mask(err)
+	}
+
+	var requeue []client.Object
+	for i := range templates.Items {
+		template := &templates.Items[i]
+		if template.DeletionTimestamp != nil {
+			continue
+		}
+		if !rolebinding.ReferencesConfigMap(*template, configMap.Namespace, configMap.Name) {
+			continue
+		}
+
+		r.logger.Debugf(ctx, "Requeueing roleBindingTemplate %s", template.Name)
+		requeue = append(requeue, template)
+	}
+
+	if err := resync.Enqueue(ctx, r.k8sClient.CtrlClient(), requeue...); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// getOrganizationForNamespace returns the organization the namespace belongs to,
+// or nil if the namespace is not labelled or the organization does not exist.
+func (r *Resource) getOrganizationForNamespace(ctx context.Context, namespace corev1.Namespace) (*security.Organization, error) {
+	name := pkgkey.Organization(&namespace)
+	if name == "" {
+		return nil, nil
+	}
+
+	organization := &security.Organization{}
+	err := r.k8sClient.CtrlClient().Get(ctx, client.ObjectKey{Name: name}, organization)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return organization, nil
+}
+
+func matchesOrganization(template v1alpha1.RoleBindingTemplate, organization *security.Organization) (bool, error) {
+	if organization == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      template.Spec.Scopes.OrganizationSelector.MatchLabels,
+		MatchExpressions: template.Spec.Scopes.OrganizationSelector.MatchExpressions,
+	})
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return selector.Matches(labels.Set(organization.Labels)), nil
+}
+
+func appliedToAny(template v1alpha1.RoleBindingTemplate, namespaces []string) bool {
+	// paused namespaces still hold the role bindings applied before
+	appliedNamespaces := append(append([]string{}, template.Status.Namespaces...), template.Status.PausedNamespaces...)
+	for _, applied := range appliedNamespaces {
+		for _, ns := range namespaces {
+			if applied == ns {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,32 +1,76 @@
 package rolebindingtemplate
 
 import (
+	"context"
+
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/v7/pkg/controller"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/rbac-operator/api/v1alpha1"
 	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/resource/templatetrigger"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
+	"github.com/giantswarm/rbac-operator/service/internal/trigger"
 )
 
 type RoleBindingTemplateConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
+	// Informers provide the organizations, organization namespaces and subjects
+	// source config maps which trigger the templates.
+	Informers  trigger.Informers
+	Protection protection.Policy
 }
 
 type RoleBindingTemplate struct {
-	*controller.Controller
+	TemplateController  *controller.Controller
+	OrganizationTrigger *trigger.Trigger
+	NamespaceTrigger    *trigger.Trigger
+	ConfigMapTrigger    *trigger.Trigger
+
+	k8sClient k8sclient.Interface
 }
 
 func NewRoleBindingTemplate(config RoleBindingTemplateConfig) (*RoleBindingTemplate, error) {
 	var err error
 
+	// scheduler is shared by all resources, so that templates are requeued
+	// once at the boundaries of their validity
+	var scheduler *resync.Scheduler
+	{
+		c := resync.SchedulerConfig{
+			CtrlClient: config.K8sClient.CtrlClient(),
+			Logger:     config.Logger,
+			NewObjectFunc: func() client.Object {
+				return new(v1alpha1.RoleBindingTemplate)
+			},
+		}
+
+		scheduler, err = resync.NewScheduler(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var resources []resource.Interface
 	{
-		c := roleBindingTemplateResourcesConfig(config)
+		c := roleBindingTemplateResourcesConfig{
+			K8sClient:    config.K8sClient,
+			Logger:       config.Logger,
+			AccessGroups: config.AccessGroups,
+			Protection:   config.Protection,
+			Scheduler:    scheduler,
+		}
 
 		resources, err = newRoleBindingTemplateResources(c)
 		if err != nil {
@@ -55,9 +99,111 @@ func NewRoleBindingTemplate(config RoleBindingTemplateConfig) (*RoleBindingTempl
 		}
 	}
 
+	var templateTrigger *templatetrigger.Resource
+	{
+		c := templatetrigger.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+		}
+
+		templateTrigger, err = templatetrigger.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var organizationTrigger *trigger.Trigger
+	{
+		c := roleBindingTemplateTriggerConfig{
+			Informers: config.Informers,
+			Logger:    config.Logger,
+			Kind:      "organization",
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(security.Organization)
+			},
+			OnChange: templateTrigger.OnChange,
+		}
+
+		organizationTrigger, err = newRoleBindingTemplateTrigger(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	// The informers only list namespaces belonging to an organization
+	var namespaceTrigger *trigger.Trigger
+	{
+		c := roleBindingTemplateTriggerConfig{
+			Informers: config.Informers,
+			Logger:    config.Logger,
+			Kind:      "namespace",
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(corev1.Namespace)
+			},
+			OnChange: templateTrigger.OnChange,
+		}
+
+		namespaceTrigger, err = newRoleBindingTemplateTrigger(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	// The informers only list config maps labelled as subjects sources
+	var configMapTrigger *trigger.Trigger
+	{
+		c := roleBindingTemplateTriggerConfig{
+			Informers: config.Informers,
+			Logger:    config.Logger,
+			Kind:      "configmap",
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(corev1.ConfigMap)
+			},
+			OnChange: templateTrigger.OnChange,
+		}
+
+		configMapTrigger, err = newRoleBindingTemplateTrigger(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	c := &RoleBindingTemplate{
-		Controller: roleBindingTemplateController,
+		TemplateController:  roleBindingTemplateController,
+		OrganizationTrigger: organizationTrigger,
+		NamespaceTrigger:    namespaceTrigger,
+		ConfigMapTrigger:    configMapTrigger,
+
+		k8sClient: config.K8sClient,
 	}
 
 	return c, nil
 }
+
+// Resync requeues all roleBindingTemplates, e.g. after the access groups changed.
+func (c *RoleBindingTemplate) Resync(ctx context.Context) error {
+	templates := &v1alpha1.RoleBindingTemplateList{}
+	err := c.k8sClient.CtrlClient().List(ctx, templates)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var objects []client.Object
+	for i := range templates.Items {
+		objects = append(objects, &templates.Items[i])
+	}
+
+	err = resync.Enqueue(ctx, c.k8sClient.CtrlClient(), objects...)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (c *RoleBindingTemplate) Boot(ctx context.Context) {
+	go c.TemplateController.Boot(ctx)
+	go c.OrganizationTrigger.Run(ctx)
+	go c.NamespaceTrigger.Run(ctx)
+	go c.ConfigMapTrigger.Run(ctx)
+}
@@ -8,12 +8,19 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/metricsresource"
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/resource/rolebinding"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/resync"
 )
 
 type roleBindingTemplateResourcesConfig struct {
 	K8sClient k8sclient.Interface
 	Logger    micrologger.Logger
+
+	AccessGroups *accessgroup.Store
+	Protection   protection.Policy
+	Scheduler    *resync.Scheduler
 }
 
 func newRoleBindingTemplateResources(config roleBindingTemplateResourcesConfig) ([]resource.Interface, error) {
@@ -24,6 +31,10 @@ func newRoleBindingTemplateResources(config roleBindingTemplateResourcesConfig)
 		c := rolebinding.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			AccessGroups: config.AccessGroups,
+			Protection:   config.Protection,
+			Scheduler:    config.Scheduler,
 		}
 
 		roleBindingResource, err = rolebinding.New(c)
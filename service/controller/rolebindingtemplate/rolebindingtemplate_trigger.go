@@ -0,0 +1,48 @@
+package rolebindingtemplate
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/service/internal/trigger"
+)
+
+type roleBindingTemplateTriggerConfig struct {
+	Informers trigger.Informers
+	Logger    micrologger.Logger
+
+	// Kind is used to name the trigger, e.g. organization or namespace.
+	Kind                 string
+	NewRuntimeObjectFunc func() client.Object
+	OnChange             func(ctx context.Context, obj client.Object) error
+}
+
+// newRoleBindingTemplateTrigger creates a trigger watching objects which
+// affect the scope or subjects of roleBindingTemplates and requeueing the matching templates.
+func newRoleBindingTemplateTrigger(config roleBindingTemplateTriggerConfig) (*trigger.Trigger, error) {
+	informer, err := config.Informers.GetInformer(context.Background(), config.NewRuntimeObjectFunc())
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var templateTrigger *trigger.Trigger
+	{
+		c := trigger.Config{
+			Informer: informer,
+			Logger:   config.Logger,
+
+			Name:     "rolebindingtemplate-" + config.Kind,
+			OnChange: config.OnChange,
+		}
+
+		templateTrigger, err = trigger.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return templateTrigger, nil
+}
@@ -0,0 +1,123 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/controller/roletemplate/key"
+)
+
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	template, err := key.ToRoleTemplate(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	namespaces, err := r.getNamespacesFromScope(ctx, template.Spec.Scopes)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	status := []string{}
+	for _, ns := range namespaces {
+		role := getRoleFromTemplate(template, ns)
+
+		applied, err := r.ensureRole(ctx, ns, role)
+		if err != nil {
+			r.logger.Debugf(ctx, "Could not apply role %s to namespace %s due to error %v", role.Name, ns, err)
+			continue
+		}
+		if applied {
+			status = append(status, ns)
+		}
+	}
+
+	// go through old list of namespaces and compare for scope changes
+	for _, ns := range template.Status.Namespaces {
+		if !contains(status, ns) {
+			if err = rbac.DeleteManagedRole(r, ctx, ns, getRoleNameFromTemplate(template)); err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	template.Status.Namespaces = status
+	if err := r.k8sClient.CtrlClient().Status().Update(ctx, &template); err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// ensureRole creates or updates the Role unless a Role with the same name exists in the namespace
+// which is not managed by rbac-operator, in which case it is left untouched and false is returned.
+func (r *Resource) ensureRole(ctx context.Context, ns string, role *rbacv1.Role) (bool, error) {
+	existing, err := r.k8sClient.K8sClient().RbacV1().Roles(ns).Get(ctx, role.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// the role is created below
+	} else if err != nil {
+		return false, microerror.Mask(err)
+	} else if existing.Labels[label.ManagedBy] != project.Name() {
+		r.logger.Debugf(ctx, "Skipping role %s in namespace %s, as it is not managed by %s", role.Name, ns, project.Name())
+		return false, nil
+	}
+
+	err = rbac.CreateOrUpdateRole(r, ctx, ns, role)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return true, nil
+}
+
+func getRoleFromTemplate(template v1alpha1.RoleTemplate, namespace string) *rbacv1.Role {
+	objectMeta := template.Spec.Template.ObjectMeta
+	{
+		// ensure namespaced name
+		objectMeta.Name = getRoleNameFromTemplate(template)
+		objectMeta.Namespace = namespace
+		// add labels and annotations
+		labels := map[string]string{}
+		for k, v := range objectMeta.GetLabels() {
+			labels[k] = v
+		}
+		labels[label.ManagedBy] = project.Name()
+		objectMeta.SetLabels(labels)
+		annotations := map[string]string{}
+		for k, v := range objectMeta.GetAnnotations() {
+			annotations[k] = v
+		}
+		if annotations[annotation.Notes] == "" {
+			annotations[annotation.Notes] = fmt.Sprintf("Generated based on RoleTemplate %s", template.Name)
+		}
+		objectMeta.SetAnnotations(annotations)
+	}
+
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: objectMeta,
+		Rules:      template.Spec.Template.Rules,
+	}
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
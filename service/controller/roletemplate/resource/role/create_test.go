@@ -0,0 +1,327 @@
+package role
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/micrologger/microloggertest"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/defaultnamespacetest"
+	"github.com/giantswarm/rbac-operator/service/test"
+)
+
+func TestGetRoleFromTemplate(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Template     v1alpha1.RoleTemplateResource
+		TemplateName string
+		Namespace    string
+
+		expectedRole *rbacv1.Role
+	}{
+		{
+			Name: "case0: add defaults",
+			Template: v1alpha1.RoleTemplateResource{
+				Rules: getTestRules(),
+			},
+			TemplateName: "read-apps",
+			Namespace:    "org-example",
+
+			expectedRole: getTestRole("read-apps", "org-example"),
+		},
+		{
+			Name: "case1: retain values and use role name from template metadata",
+			Template: v1alpha1.RoleTemplateResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "apps-reader",
+					Labels: map[string]string{
+						"the-label": "the-value",
+					},
+					Annotations: map[string]string{
+						annotation.Notes: "There is already a note here",
+					},
+				},
+				Rules: getTestRules(),
+			},
+			TemplateName: "read-apps",
+			Namespace:    "org-example",
+
+			expectedRole: &rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Role",
+					APIVersion: "rbac.authorization.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "apps-reader",
+					Namespace: "org-example",
+					Labels: map[string]string{
+						"the-label":     "the-value",
+						label.ManagedBy: project.Name(),
+					},
+					Annotations: map[string]string{
+						annotation.Notes: "There is already a note here",
+					},
+				},
+				Rules: getTestRules(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			template := v1alpha1.RoleTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tc.TemplateName,
+				},
+				Spec: v1alpha1.RoleTemplateSpec{
+					Template: tc.Template,
+				},
+			}
+
+			result := getRoleFromTemplate(template, tc.Namespace)
+			if !reflect.DeepEqual(tc.expectedRole, result) {
+				t.Fatalf("Expected\n%v\n\n...to be equal to:\n%v\n", result, tc.expectedRole)
+			}
+		})
+	}
+}
+
+func TestEnsureCreated(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Template      *v1alpha1.RoleTemplate
+		Organizations []string
+		Clusters      map[string]string
+		ExistingRoles []runtime.Object
+
+		expectedRoles  []*rbacv1.Role
+		expectedStatus []string
+	}{
+		{
+			Name:          "case0: create roles in all org and cluster namespaces",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, nil),
+			Organizations: []string{"example", "example-2"},
+			Clusters: map[string]string{
+				"abc12": "example",
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getTestRole("read-apps", "org-example"),
+				getTestRole("read-apps", "abc12"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+			expectedStatus: []string{"org-example", "abc12", "org-example-2"},
+		},
+		{
+			Name: "case1: create roles for one org",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example-2"},
+			}, nil),
+			Organizations: []string{"example", "example-2"},
+			Clusters: map[string]string{
+				"abc12": "example",
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getTestRole("read-apps", "org-example-2"),
+			},
+			expectedStatus: []string{"org-example-2"},
+		},
+		{
+			Name: "case2: remove roles from namespaces that are out of scope",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example-2"},
+			}, []string{"org-example", "abc12", "org-example-2"}),
+			Organizations: []string{"example", "example-2"},
+			Clusters: map[string]string{
+				"abc12": "example",
+			},
+			ExistingRoles: []runtime.Object{
+				getTestRole("read-apps", "org-example"),
+				getTestRole("read-apps", "abc12"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getTestRole("read-apps", "org-example-2"),
+			},
+			expectedStatus: []string{"org-example-2"},
+		},
+		{
+			Name:          "case3: do not overwrite roles not managed by rbac-operator",
+			Template:      getTestTemplate(v1alpha1.ScopeSelector{}, nil),
+			Organizations: []string{"example", "example-2"},
+			ExistingRoles: []runtime.Object{
+				getUnmanagedRole("read-apps", "org-example"),
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getUnmanagedRole("read-apps", "org-example"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+			expectedStatus: []string{"org-example-2"},
+		},
+		{
+			Name: "case4: do not remove roles not managed by rbac-operator from namespaces that are out of scope",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{
+				MatchLabels: map[string]string{"name": "example-2"},
+			}, []string{"org-example", "org-example-2"}),
+			Organizations: []string{"example", "example-2"},
+			ExistingRoles: []runtime.Object{
+				getUnmanagedRole("read-apps", "org-example"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getUnmanagedRole("read-apps", "org-example"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+			expectedStatus: []string{"org-example-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			objects := []runtime.Object{tc.Template}
+			k8sObjects := []runtime.Object{}
+			for _, org := range tc.Organizations {
+				organization := test.NewOrganization(org)
+				organization.Labels = map[string]string{"name": org}
+				objects = append(objects, organization)
+				k8sObjects = append(k8sObjects, test.NewOrgNamespace(org))
+			}
+			for cluster, org := range tc.Clusters {
+				k8sObjects = append(k8sObjects, test.NewClusterNamespace(cluster, org))
+			}
+			k8sObjects = append(k8sObjects, tc.ExistingRoles...)
+
+			var k8sClientFake *k8sclienttest.Clients
+			{
+				schemeBuilder := runtime.SchemeBuilder{
+					security.AddToScheme,
+					v1alpha1.AddToScheme,
+				}
+				if err := schemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+					t.Fatal(err)
+				}
+
+				k8sClientFake = k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+					CtrlClient: clientfake.NewClientBuilder().
+						WithScheme(scheme.Scheme).
+						WithRuntimeObjects(objects...).
+						WithStatusSubresource(&v1alpha1.RoleTemplate{}).
+						Build(),
+					K8sClient: clientgofake.NewSimpleClientset(k8sObjects...),
+				})
+			}
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			err = r.EnsureCreated(ctx, tc.Template)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			roleList, err := k8sClientFake.K8sClient().RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to get roles: %s", err)
+			}
+			defaultnamespacetest.RolesShouldEqual(t, tc.expectedRoles, roleList.Items)
+
+			template := &v1alpha1.RoleTemplate{}
+			err = k8sClientFake.CtrlClient().Get(ctx, client.ObjectKey{Name: tc.Template.Name}, template)
+			if err != nil {
+				t.Fatalf("failed to get template: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expectedStatus, template.Status.Namespaces) {
+				t.Fatalf("Expected status %v, got %v", tc.expectedStatus, template.Status.Namespaces)
+			}
+		})
+	}
+}
+
+func getTestTemplate(selector v1alpha1.ScopeSelector, status []string) *v1alpha1.RoleTemplate {
+	return &v1alpha1.RoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "read-apps",
+		},
+		Spec: v1alpha1.RoleTemplateSpec{
+			Template: v1alpha1.RoleTemplateResource{
+				Rules: getTestRules(),
+			},
+			Scopes: v1alpha1.RoleTemplateScopes{
+				OrganizationSelector: selector,
+			},
+		},
+		Status: v1alpha1.RoleTemplateStatus{
+			Namespaces: status,
+		},
+	}
+}
+
+func getTestRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"application.giantswarm.io"},
+			Resources: []string{"apps"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+}
+
+func getTestRole(name, namespace string) *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				label.ManagedBy: project.Name(),
+			},
+			Annotations: map[string]string{
+				annotation.Notes: "Generated based on RoleTemplate read-apps",
+			},
+		},
+		Rules: getTestRules(),
+	}
+}
+
+func getUnmanagedRole(name, namespace string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			},
+		},
+	}
+}
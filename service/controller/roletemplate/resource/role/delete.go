@@ -0,0 +1,25 @@
+package role
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/rbac-operator/pkg/rbac"
+	"github.com/giantswarm/rbac-operator/service/controller/roletemplate/key"
+)
+
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	template, err := key.ToRoleTemplate(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, ns := range template.Status.Namespaces {
+		if err = rbac.DeleteManagedRole(r, ctx, ns, getRoleNameFromTemplate(template)); err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace/defaultnamespacetest"
+	"github.com/giantswarm/rbac-operator/service/test"
+)
+
+func TestEnsureDeleted(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Template      *v1alpha1.RoleTemplate
+		ExistingRoles []runtime.Object
+
+		expectedRoles []*rbacv1.Role
+	}{
+		{
+			Name:     "case0: remove roles from all namespaces",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{}, []string{"org-example", "org-example-2"}),
+			ExistingRoles: []runtime.Object{
+				getTestRole("read-apps", "org-example"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+
+			expectedRoles: []*rbacv1.Role{},
+		},
+		{
+			Name:     "case1: do not remove roles not managed by rbac-operator",
+			Template: getTestTemplate(v1alpha1.ScopeSelector{}, []string{"org-example", "org-example-2"}),
+			ExistingRoles: []runtime.Object{
+				getUnmanagedRole("read-apps", "org-example"),
+				getTestRole("read-apps", "org-example-2"),
+			},
+
+			expectedRoles: []*rbacv1.Role{
+				getUnmanagedRole("read-apps", "org-example"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			k8sObjects := []runtime.Object{
+				test.NewOrgNamespace("example"),
+				test.NewOrgNamespace("example-2"),
+			}
+			k8sObjects = append(k8sObjects, tc.ExistingRoles...)
+
+			k8sClientFake := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+				K8sClient: clientgofake.NewSimpleClientset(k8sObjects...),
+			})
+
+			r, err := New(Config{
+				K8sClient: k8sClientFake,
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			err = r.EnsureDeleted(ctx, tc.Template)
+			if err != nil {
+				t.Fatalf("Expected success, got error %v", err)
+			}
+
+			roleList, err := k8sClientFake.K8sClient().RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to get roles: %s", err)
+			}
+			defaultnamespacetest.RolesShouldEqual(t, tc.expectedRoles, roleList.Items)
+		})
+	}
+}
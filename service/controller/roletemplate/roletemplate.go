@@ -0,0 +1,63 @@
+package roletemplate
+
+import (
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/controller"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	"github.com/giantswarm/rbac-operator/pkg/project"
+)
+
+type RoleTemplateConfig struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+}
+
+type RoleTemplate struct {
+	*controller.Controller
+}
+
+func NewRoleTemplate(config RoleTemplateConfig) (*RoleTemplate, error) {
+	var err error
+
+	var resources []resource.Interface
+	{
+		c := roleTemplateResourcesConfig(config)
+
+		resources, err = newRoleTemplateResources(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var roleTemplateController *controller.Controller
+	{
+		c := controller.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
+			NewRuntimeObjectFunc: func() client.Object {
+				return new(v1alpha1.RoleTemplate)
+			},
+			Resources: resources,
+
+			// Name is used to compute finalizer names. This here results in something
+			// like operatorkit.giantswarm.io/rbac-operator-roletemplate-controller.
+			Name: project.Name() + "-roletemplate-controller",
+		}
+
+		roleTemplateController, err = controller.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	c := &RoleTemplate{
+		Controller: roleTemplateController,
+	}
+
+	return c, nil
+}
@@ -1,15 +1,49 @@
 package accessgroup
 
-import rbacv1 "k8s.io/api/rbac/v1"
+import (
+	"fmt"
+	"strings"
 
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// AccessGroup is a subject access is granted to. It is a group unless Kind is
+// User or ServiceAccount, ServiceAccounts also need a Namespace.
 type AccessGroup struct {
-	Name string
+	Name      string
+	Kind      string
+	Namespace string
+}
+
+// subjectKind returns the kind of the subject, Group if not set
+func (g AccessGroup) subjectKind() string {
+	if g.Kind == "" {
+		return rbacv1.GroupKind
+	}
+	return g.Kind
+}
+
+func (g AccessGroup) sameSubject(other AccessGroup) bool {
+	return g.subjectKind() == other.subjectKind() && g.Name == other.Name && g.Namespace == other.Namespace
 }
 
 type AccessGroups struct {
 	WriteAllCustomerGroups   []AccessGroup
 	ReadAllCustomerGroups    []AccessGroup
 	WriteAllGiantswarmGroups []AccessGroup
+
+	// Tiers are further groups bound to configurable ClusterRoles.
+	Tiers []AccessTier
+
+	// Organizations are groups granted access to single organizations, by organization name.
+	Organizations map[string]OrganizationAccessGroups
+
+	// GroupPrefixes are the prefixes the names of groups must start with, by tier name,
+	// e.g. the --oidc-groups-prefix of the API server.
+	GroupPrefixes map[string]string
+	// AddMissingGroupPrefixes adds the prefix of the tier to groups missing it
+	// instead of rejecting them.
+	AddMissingGroupPrefixes bool
 }
 
 func (a *AccessGroups) AddLegacyCustomerAdminGroup(legacyGroupName string) {
@@ -28,23 +62,61 @@ func addLegacyGroupIfMissing(groups []AccessGroup, legacyGroupName string) []Acc
 	if legacyGroupName == "" {
 		return groups
 	}
-	for _, group := range groups {
-		if group.Name == legacyGroupName {
+	return addGroupIfMissing(groups, AccessGroup{Name: legacyGroupName})
+}
+
+func addGroupIfMissing(groups []AccessGroup, group AccessGroup) []AccessGroup {
+	if group.Name == "" {
+		return groups
+	}
+	for _, existing := range groups {
+		if existing.sameSubject(group) {
 			return groups
 		}
 	}
-	return append(groups, AccessGroup{Name: legacyGroupName})
+	return append(groups, group)
+}
+
+// ParseGroups returns the groups in a list of group names separated by newlines or commas
+func ParseGroups(value string) []AccessGroup {
+	var groups []AccessGroup
+	for _, line := range strings.Split(value, "\n") {
+		for _, name := range strings.Split(line, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			groups = append(groups, AccessGroup{Name: name})
+		}
+	}
+	return groups
+}
+
+// MergeGroups returns the groups of all lists, each subject only once
+func MergeGroups(lists ...[]AccessGroup) []AccessGroup {
+	var merged []AccessGroup
+	for _, groups := range lists {
+		for _, group := range groups {
+			merged = addGroupIfMissing(merged, group)
+		}
+	}
+	return merged
 }
 
 func GroupsToSubjects(groups []AccessGroup) []rbacv1.Subject {
 	var subjects []rbacv1.Subject
 	for _, group := range groups {
-		if group.Name != "" {
-			subjects = append(subjects, rbacv1.Subject{
-				Kind: "Group",
-				Name: group.Name,
-			})
+		if group.Name == "" {
+			continue
+		}
+		subject := rbacv1.Subject{
+			Kind: group.subjectKind(),
+			Name: group.Name,
 		}
+		if subject.Kind == rbacv1.ServiceAccountKind {
+			subject.Namespace = group.Namespace
+		}
+		subjects = append(subjects, subject)
 	}
 	return subjects
 }
@@ -57,3 +129,36 @@ func ValidateGroups(groups []AccessGroup) bool {
 	}
 	return false
 }
+
+// validateSubjects returns a message for the first invalid group.
+func validateSubjects(field string, groups []AccessGroup) string {
+	for i, group := range groups {
+		if message := validateSubject(fmt.Sprintf("%s[%d]", field, i), group); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// validateSubject returns a message if a group has no name or an unknown kind,
+// or if a ServiceAccount has no namespace or another kind has one.
+func validateSubject(field string, group AccessGroup) string {
+	if strings.TrimSpace(group.Name) == "" {
+		return fmt.Sprintf("%s has no name", field)
+	}
+
+	switch group.subjectKind() {
+	case rbacv1.GroupKind, rbacv1.UserKind:
+		if group.Namespace != "" {
+			return fmt.Sprintf("%s of kind %s must not have a namespace", field, group.subjectKind())
+		}
+	case rbacv1.ServiceAccountKind:
+		if group.Namespace == "" {
+			return fmt.Sprintf("%s of kind %s must have a namespace", field, group.subjectKind())
+		}
+	default:
+		return fmt.Sprintf("%s has unknown kind %#q, must be one of %s, %s or %s", field, group.Kind, rbacv1.GroupKind, rbacv1.UserKind, rbacv1.ServiceAccountKind)
+	}
+
+	return ""
+}
@@ -1,6 +1,11 @@
 package accessgroup
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
 
 func Test_LegacyGroupsAreApplied(t *testing.T) {
 	accessGroups := []AccessGroup{
@@ -18,3 +23,97 @@ func Test_LegacyGroupsAreApplied(t *testing.T) {
 		t.Fatalf("Incorrect length of access groups - expected: 3, actual: %d", len(accessGroups))
 	}
 }
+
+func Test_GroupsToSubjects(t *testing.T) {
+	groups := []AccessGroup{
+		{Name: "customer:acme:Admins"},
+		{Name: "jane@acme.com", Kind: rbacv1.UserKind},
+		{Name: "deployer", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"},
+		{Name: ""},
+	}
+
+	expected := []rbacv1.Subject{
+		{Kind: rbacv1.GroupKind, Name: "customer:acme:Admins"},
+		{Kind: rbacv1.UserKind, Name: "jane@acme.com"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: "org-acme"},
+	}
+
+	subjects := GroupsToSubjects(groups)
+	if !reflect.DeepEqual(expected, subjects) {
+		t.Fatalf("Expected subjects %v, got %v", expected, subjects)
+	}
+}
+
+func Test_MergeGroups(t *testing.T) {
+	merged := MergeGroups(
+		[]AccessGroup{{Name: "acme"}, {Name: "acme", Kind: rbacv1.UserKind}},
+		[]AccessGroup{{Name: "acme", Kind: rbacv1.GroupKind}, {Name: "acme", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"}},
+	)
+
+	expected := []AccessGroup{
+		{Name: "acme"},
+		{Name: "acme", Kind: rbacv1.UserKind},
+		{Name: "acme", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"},
+	}
+	if !reflect.DeepEqual(expected, merged) {
+		t.Fatalf("Expected groups %v, got %v", expected, merged)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		accessGroups AccessGroups
+		expectValid  bool
+	}{
+		{
+			name: "case 0: groups, users and service accounts",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{
+					{Name: "customer:acme:Admins"},
+					{Name: "jane@acme.com", Kind: rbacv1.UserKind},
+					{Name: "deployer", Kind: rbacv1.ServiceAccountKind, Namespace: "org-acme"},
+				},
+			},
+			expectValid: true,
+		},
+		{
+			name: "case 1: unknown kind",
+			accessGroups: AccessGroups{
+				ReadAllCustomerGroups: []AccessGroup{{Name: "acme", Kind: "Team"}},
+			},
+		},
+		{
+			name: "case 2: service account without namespace",
+			accessGroups: AccessGroups{
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "deployer", Kind: rbacv1.ServiceAccountKind}},
+			},
+		},
+		{
+			name: "case 3: user with namespace",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{{Name: "jane@acme.com", Kind: rbacv1.UserKind, Namespace: "org-acme"}},
+			},
+		},
+		{
+			name: "case 4: service account without namespace in a tier",
+			accessGroups: AccessGroups{
+				Tiers: []AccessTier{
+					{Name: "platform", Groups: []AccessGroup{{Name: "deployer", Kind: rbacv1.ServiceAccountKind}}, ClusterRoles: []string{"write-silences"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := tc.accessGroups.Validate()
+			if tc.expectValid && message != "" {
+				t.Fatalf("Expected access groups to be valid, got %q", message)
+			}
+			if !tc.expectValid && message == "" {
+				t.Fatalf("Expected access groups to be invalid")
+			}
+		})
+	}
+}
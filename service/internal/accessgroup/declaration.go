@@ -0,0 +1,139 @@
+package accessgroup
+
+import (
+	"fmt"
+)
+
+// OrganizationAccessGroups are granted access to the namespaces of a single
+// organization only.
+type OrganizationAccessGroups struct {
+	AdminGroups  []AccessGroup
+	ReaderGroups []AccessGroup
+	// TierGroups are the groups bound to the namespace ClusterRoles of access tiers, by tier name.
+	TierGroups map[string][]AccessGroup
+}
+
+// Groups returns the groups granted the tier in the organization.
+func (o OrganizationAccessGroups) Groups(tier string) []AccessGroup {
+	switch tier {
+	case CustomerAdminsTierName:
+		return o.AdminGroups
+	case CustomerReadersTierName:
+		return o.ReaderGroups
+	default:
+		return o.TierGroups[tier]
+	}
+}
+
+// Declaration grants groups an access tier, e.g. declared using an AccessGroup
+// resource. The tier is granted in the given organizations only, or everywhere
+// if there are none.
+type Declaration struct {
+	Tier          string
+	Groups        []AccessGroup
+	Organizations []string
+}
+
+// ValidateDeclaration returns a message if the tier of the declaration is not
+// configured or its groups are invalid.
+func (a *AccessGroups) ValidateDeclaration(declaration Declaration) string {
+	if message := validateSubjects("subjects", declaration.Groups); message != "" {
+		return message
+	}
+	if !ValidateGroups(declaration.Groups) {
+		return "subjects must not be empty"
+	}
+
+	switch declaration.Tier {
+	case CustomerAdminsTierName, CustomerReadersTierName:
+		return ""
+	case GiantswarmAdminsTierName:
+		if len(declaration.Organizations) > 0 {
+			return fmt.Sprintf("tier %#q can not be restricted to organizations", declaration.Tier)
+		}
+		return ""
+	}
+
+	for _, tier := range a.Tiers {
+		if tier.Name == declaration.Tier {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("tier %#q is not configured", declaration.Tier)
+}
+
+// WithDeclarations returns a copy of the access groups with the groups of the
+// valid declarations added.
+func (a AccessGroups) WithDeclarations(declarations []Declaration) AccessGroups {
+	merged := AccessGroups{
+		WriteAllCustomerGroups:   MergeGroups(a.WriteAllCustomerGroups),
+		ReadAllCustomerGroups:    MergeGroups(a.ReadAllCustomerGroups),
+		WriteAllGiantswarmGroups: MergeGroups(a.WriteAllGiantswarmGroups),
+	}
+	for _, tier := range a.Tiers {
+		tier.Groups = MergeGroups(tier.Groups)
+		merged.Tiers = append(merged.Tiers, tier)
+	}
+	for name, groups := range a.Organizations {
+		merged.addOrganizationGroups(name, groups)
+	}
+
+	for _, declaration := range declarations {
+		if a.ValidateDeclaration(declaration) != "" {
+			continue
+		}
+
+		if len(declaration.Organizations) > 0 {
+			for _, name := range declaration.Organizations {
+				merged.addOrganizationGroups(name, declaration.organizationGroups())
+			}
+			continue
+		}
+
+		switch declaration.Tier {
+		case CustomerAdminsTierName:
+			merged.WriteAllCustomerGroups = MergeGroups(merged.WriteAllCustomerGroups, declaration.Groups)
+		case CustomerReadersTierName:
+			merged.ReadAllCustomerGroups = MergeGroups(merged.ReadAllCustomerGroups, declaration.Groups)
+		case GiantswarmAdminsTierName:
+			merged.WriteAllGiantswarmGroups = MergeGroups(merged.WriteAllGiantswarmGroups, declaration.Groups)
+		default:
+			for i := range merged.Tiers {
+				if merged.Tiers[i].Name == declaration.Tier {
+					merged.Tiers[i].Groups = MergeGroups(merged.Tiers[i].Groups, declaration.Groups)
+				}
+			}
+		}
+	}
+
+	return merged
+}
+
+func (d Declaration) organizationGroups() OrganizationAccessGroups {
+	switch d.Tier {
+	case CustomerAdminsTierName:
+		return OrganizationAccessGroups{AdminGroups: d.Groups}
+	case CustomerReadersTierName:
+		return OrganizationAccessGroups{ReaderGroups: d.Groups}
+	default:
+		return OrganizationAccessGroups{TierGroups: map[string][]AccessGroup{d.Tier: d.Groups}}
+	}
+}
+
+func (a *AccessGroups) addOrganizationGroups(name string, groups OrganizationAccessGroups) {
+	if a.Organizations == nil {
+		a.Organizations = map[string]OrganizationAccessGroups{}
+	}
+
+	current := a.Organizations[name]
+	current.AdminGroups = MergeGroups(current.AdminGroups, groups.AdminGroups)
+	current.ReaderGroups = MergeGroups(current.ReaderGroups, groups.ReaderGroups)
+	for tier, tierGroups := range groups.TierGroups {
+		if current.TierGroups == nil {
+			current.TierGroups = map[string][]AccessGroup{}
+		}
+		current.TierGroups[tier] = MergeGroups(current.TierGroups[tier], tierGroups)
+	}
+	a.Organizations[name] = current
+}
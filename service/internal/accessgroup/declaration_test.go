@@ -0,0 +1,87 @@
+package accessgroup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ValidateDeclaration(t *testing.T) {
+	accessGroups := AccessGroups{
+		Tiers: []AccessTier{{Name: "platform", ClusterRoles: []string{"write-silences"}}},
+	}
+
+	testCases := []struct {
+		name        string
+		declaration Declaration
+		expectValid bool
+	}{
+		{
+			name:        "case 0: built-in tier",
+			declaration: Declaration{Tier: CustomerAdminsTierName, Groups: []AccessGroup{{Name: "admins"}}},
+			expectValid: true,
+		},
+		{
+			name:        "case 1: configured tier in an organization",
+			declaration: Declaration{Tier: "platform", Groups: []AccessGroup{{Name: "platform"}}, Organizations: []string{"acme"}},
+			expectValid: true,
+		},
+		{
+			name:        "case 2: tier not configured",
+			declaration: Declaration{Tier: "unknown", Groups: []AccessGroup{{Name: "platform"}}},
+		},
+		{
+			name:        "case 3: Giant Swarm admins in an organization",
+			declaration: Declaration{Tier: GiantswarmAdminsTierName, Groups: []AccessGroup{{Name: "admins"}}, Organizations: []string{"acme"}},
+		},
+		{
+			name:        "case 4: service account without namespace",
+			declaration: Declaration{Tier: CustomerReadersTierName, Groups: []AccessGroup{{Name: "reader", Kind: "ServiceAccount"}}},
+		},
+		{
+			name:        "case 5: no subjects",
+			declaration: Declaration{Tier: CustomerReadersTierName},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := accessGroups.ValidateDeclaration(tc.declaration)
+			if tc.expectValid && message != "" {
+				t.Fatalf("Expected declaration to be valid, got %q", message)
+			}
+			if !tc.expectValid && message == "" {
+				t.Fatalf("Expected declaration to be invalid")
+			}
+		})
+	}
+}
+
+func Test_WithDeclarations(t *testing.T) {
+	configured := AccessGroups{
+		WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}},
+		WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+		Tiers:                    []AccessTier{{Name: "platform", ClusterRoles: []string{"write-silences"}}},
+	}
+
+	merged := configured.WithDeclarations([]Declaration{
+		{Tier: CustomerAdminsTierName, Groups: []AccessGroup{{Name: "admins"}, {Name: "jane", Kind: "User"}}},
+		{Tier: "platform", Groups: []AccessGroup{{Name: "platform"}}},
+		{Tier: CustomerReadersTierName, Groups: []AccessGroup{{Name: "readers"}}, Organizations: []string{"acme"}},
+		{Tier: "unknown", Groups: []AccessGroup{{Name: "ignored"}}},
+	})
+
+	expected := AccessGroups{
+		WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}, {Name: "jane", Kind: "User"}},
+		WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+		Tiers:                    []AccessTier{{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}, ClusterRoles: []string{"write-silences"}}},
+		Organizations: map[string]OrganizationAccessGroups{
+			"acme": {ReaderGroups: []AccessGroup{{Name: "readers"}}},
+		},
+	}
+	if !reflect.DeepEqual(expected, merged) {
+		t.Fatalf("Expected access groups %v, got %v", expected, merged)
+	}
+	if len(configured.Tiers[0].Groups) != 0 {
+		t.Fatalf("Expected configured access groups to be unchanged, got %v", configured)
+	}
+}
@@ -0,0 +1,87 @@
+package accessgroup
+
+import (
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// RejectedGroup is a group which is not bound since its name lacks the group
+// prefix of its tier.
+type RejectedGroup struct {
+	Tier   string
+	Name   string
+	Prefix string
+}
+
+// NormalizeGroups returns the groups of a tier with the missing group prefixes
+// added if AddMissingGroupPrefixes is set, or else without the groups missing
+// it. Only subjects of kind Group are checked.
+func (a *AccessGroups) NormalizeGroups(tier string, groups []AccessGroup) ([]AccessGroup, []RejectedGroup) {
+	prefix := a.GroupPrefixes[tier]
+	if prefix == "" {
+		return groups, nil
+	}
+
+	var normalized []AccessGroup
+	var rejected []RejectedGroup
+	for _, group := range groups {
+		if group.subjectKind() != rbacv1.GroupKind || group.Name == "" || strings.HasPrefix(group.Name, prefix) {
+			normalized = append(normalized, group)
+			continue
+		}
+
+		if a.AddMissingGroupPrefixes {
+			group.Name = prefix + group.Name
+			normalized = append(normalized, group)
+			continue
+		}
+
+		rejected = append(rejected, RejectedGroup{Tier: tier, Name: group.Name, Prefix: prefix})
+	}
+
+	return MergeGroups(normalized), rejected
+}
+
+// Normalize returns a copy of the access groups with the groups of all tiers
+// normalized, and the groups which were rejected.
+func (a AccessGroups) Normalize() (AccessGroups, []RejectedGroup) {
+	if len(a.GroupPrefixes) == 0 {
+		return a, nil
+	}
+
+	var rejected []RejectedGroup
+	normalize := func(tier string, groups []AccessGroup) []AccessGroup {
+		normalized, r := a.NormalizeGroups(tier, groups)
+		rejected = append(rejected, r...)
+		return normalized
+	}
+
+	normalized := a
+	normalized.WriteAllCustomerGroups = normalize(CustomerAdminsTierName, a.WriteAllCustomerGroups)
+	normalized.ReadAllCustomerGroups = normalize(CustomerReadersTierName, a.ReadAllCustomerGroups)
+	normalized.WriteAllGiantswarmGroups = normalize(GiantswarmAdminsTierName, a.WriteAllGiantswarmGroups)
+
+	normalized.Tiers = nil
+	for _, tier := range a.Tiers {
+		tier.Groups = normalize(tier.Name, tier.Groups)
+		normalized.Tiers = append(normalized.Tiers, tier)
+	}
+
+	normalized.Organizations = nil
+	for name, groups := range a.Organizations {
+		organizationGroups := OrganizationAccessGroups{
+			AdminGroups:  normalize(CustomerAdminsTierName, groups.AdminGroups),
+			ReaderGroups: normalize(CustomerReadersTierName, groups.ReaderGroups),
+		}
+		for tier, tierGroups := range groups.TierGroups {
+			if organizationGroups.TierGroups == nil {
+				organizationGroups.TierGroups = map[string][]AccessGroup{}
+			}
+			organizationGroups.TierGroups[tier] = normalize(tier, tierGroups)
+		}
+		normalized.addOrganizationGroups(name, organizationGroups)
+	}
+
+	return normalized, rejected
+}
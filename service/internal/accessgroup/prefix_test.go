@@ -0,0 +1,89 @@
+package accessgroup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		accessGroups         AccessGroups
+		expectedAccessGroups AccessGroups
+		expectedRejected     []RejectedGroup
+	}{
+		{
+			name: "case 0: access groups are unchanged without prefixes",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{{Name: "admins"}},
+			},
+			expectedAccessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{{Name: "admins"}},
+			},
+		},
+		{
+			name: "case 1: groups lacking the prefix are rejected, users and service accounts are kept",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{
+					{Name: "customer:admins"},
+					{Name: "admins"},
+					{Name: "admin", Kind: "User"},
+					{Name: "automation", Kind: "ServiceAccount", Namespace: "default"},
+				},
+				ReadAllCustomerGroups: []AccessGroup{{Name: "readers"}},
+				Tiers: []AccessTier{
+					{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}},
+				},
+				Organizations: map[string]OrganizationAccessGroups{
+					"acme": {TierGroups: map[string][]AccessGroup{"platform": {{Name: "acme-platform"}, {Name: "platform:acme"}}}},
+				},
+				GroupPrefixes: map[string]string{CustomerAdminsTierName: "customer:", "platform": "platform:"},
+			},
+			expectedAccessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{
+					{Name: "customer:admins"},
+					{Name: "admin", Kind: "User"},
+					{Name: "automation", Kind: "ServiceAccount", Namespace: "default"},
+				},
+				ReadAllCustomerGroups: []AccessGroup{{Name: "readers"}},
+				Tiers: []AccessTier{
+					{Name: "platform"},
+				},
+				Organizations: map[string]OrganizationAccessGroups{
+					"acme": {TierGroups: map[string][]AccessGroup{"platform": {{Name: "platform:acme"}}}},
+				},
+				GroupPrefixes: map[string]string{CustomerAdminsTierName: "customer:", "platform": "platform:"},
+			},
+			expectedRejected: []RejectedGroup{
+				{Tier: CustomerAdminsTierName, Name: "admins", Prefix: "customer:"},
+				{Tier: "platform", Name: "platform", Prefix: "platform:"},
+				{Tier: "platform", Name: "acme-platform", Prefix: "platform:"},
+			},
+		},
+		{
+			name: "case 2: missing prefixes are added if enabled",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups:  []AccessGroup{{Name: "customer:admins"}, {Name: "admins"}},
+				GroupPrefixes:           map[string]string{CustomerAdminsTierName: "customer:"},
+				AddMissingGroupPrefixes: true,
+			},
+			expectedAccessGroups: AccessGroups{
+				WriteAllCustomerGroups:  []AccessGroup{{Name: "customer:admins"}},
+				GroupPrefixes:           map[string]string{CustomerAdminsTierName: "customer:"},
+				AddMissingGroupPrefixes: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accessGroups, rejected := tc.accessGroups.Normalize()
+			if !reflect.DeepEqual(tc.expectedAccessGroups, accessGroups) {
+				t.Fatalf("Expected access groups %v, got %v", tc.expectedAccessGroups, accessGroups)
+			}
+			if !reflect.DeepEqual(tc.expectedRejected, rejected) {
+				t.Fatalf("Expected rejected groups %v, got %v", tc.expectedRejected, rejected)
+			}
+		})
+	}
+}
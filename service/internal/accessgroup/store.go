@@ -0,0 +1,124 @@
+package accessgroup
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Store holds the access groups the operator is configured with, combined
+// with the declared ones and normalized to the configured group prefixes.
+// Resources read the groups on every reconciliation, so that they can be
+// replaced while the operator is running.
+type Store struct {
+	mutex        sync.RWMutex
+	configured   AccessGroups
+	declarations []Declaration
+	groups       AccessGroups
+	rejected     []RejectedGroup
+
+	// valid is false while the configuration read last is rejected and the
+	// configured access groups read before are still used.
+	valid    bool
+	warnings []string
+}
+
+func NewStore(groups AccessGroups) *Store {
+	s := &Store{
+		configured: groups,
+		valid:      true,
+		warnings:   groups.Check().Warnings,
+	}
+	s.update()
+
+	return s
+}
+
+// Get returns the current access groups.
+func (s *Store) Get() AccessGroups {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.groups
+}
+
+// Configured returns the access groups the operator is configured with,
+// without the declared ones.
+func (s *Store) Configured() AccessGroups {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.configured
+}
+
+// Rejected returns the groups which are not bound since they lack the group
+// prefix of their tier.
+func (s *Store) Rejected() []RejectedGroup {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.rejected
+}
+
+// Valid reports whether the configuration read last is valid.
+func (s *Store) Valid() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.valid
+}
+
+// Warnings returns the warnings about the configured access groups.
+func (s *Store) Warnings() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.warnings
+}
+
+// Set replaces the configured access groups and reports whether the current
+// access groups changed.
+func (s *Store) Set(groups AccessGroups) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.configured = groups
+	s.valid = true
+	s.warnings = groups.Check().Warnings
+
+	return s.update()
+}
+
+// SetInvalid records that the configuration read last was rejected, the
+// configured access groups are kept.
+func (s *Store) SetInvalid() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.valid = false
+}
+
+// SetDeclarations replaces the declared access groups and reports whether the
+// current access groups changed.
+func (s *Store) SetDeclarations(declarations []Declaration) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.declarations = declarations
+
+	return s.update()
+}
+
+func (s *Store) update() bool {
+	groups := s.configured
+	if len(s.declarations) > 0 {
+		groups = s.configured.WithDeclarations(s.declarations)
+	}
+	groups, s.rejected = groups.Normalize()
+
+	if reflect.DeepEqual(s.groups, groups) {
+		return false
+	}
+	s.groups = groups
+
+	return true
+}
@@ -0,0 +1,32 @@
+package accessgroup
+
+import (
+	"testing"
+)
+
+func Test_StoreValidity(t *testing.T) {
+	duplicated := AccessGroups{
+		WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}, {Name: "admins"}},
+		WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+	}
+	valid := AccessGroups{
+		WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}},
+		WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+	}
+
+	s := NewStore(duplicated)
+	if !s.Valid() || len(s.Warnings()) != 1 {
+		t.Fatalf("Expected valid access groups with 1 warning, got valid %t with warnings %v", s.Valid(), s.Warnings())
+	}
+
+	// a rejected configuration keeps the access groups and their warnings
+	s.SetInvalid()
+	if s.Valid() || len(s.Warnings()) != 1 {
+		t.Fatalf("Expected invalid access groups with 1 warning, got valid %t with warnings %v", s.Valid(), s.Warnings())
+	}
+
+	s.Set(valid)
+	if !s.Valid() || len(s.Warnings()) != 0 {
+		t.Fatalf("Expected valid access groups without warnings, got valid %t with warnings %v", s.Valid(), s.Warnings())
+	}
+}
@@ -0,0 +1,127 @@
+package accessgroup
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+)
+
+// Names of the default tiers whose groups are configured using WriteAllCustomerGroups,
+// ReadAllCustomerGroups and WriteAllGiantswarmGroups. They can not be used
+// for further tiers.
+const (
+	CustomerAdminsTierName   = "customer-admins"
+	CustomerReadersTierName  = "customer-readers"
+	GiantswarmAdminsTierName = "giantswarm-admins"
+)
+
+// AccessTier grants its groups ClusterRoles cluster-wide, in every organization
+// namespace and in every cluster namespace.
+type AccessTier struct {
+	Name   string
+	Groups []AccessGroup
+
+	// ClusterRoles are bound using ClusterRoleBindings.
+	ClusterRoles []string
+	// OrganizationNamespaceClusterRoles are bound using RoleBindings in every organization namespace.
+	OrganizationNamespaceClusterRoles []string
+	// ClusterNamespaceClusterRoles are bound using RoleBindings in every cluster namespace.
+	ClusterNamespaceClusterRoles []string
+
+	// BindingNames are the names of the bindings of ClusterRoles, by ClusterRole.
+	// Only the default tiers set them, so that their bindings keep the names
+	// they had before tiers could be configured.
+	BindingNames map[string]string
+}
+
+// BindingName returns the name of the ClusterRoleBinding and RoleBindings binding
+// a ClusterRole of the tier, access-tier-<tier>-<clusterrole> unless set in BindingNames.
+// Bindings of the same name, e.g. of several tiers binding read-all, are merged.
+func (t AccessTier) BindingName(clusterRole string) string {
+	if name, ok := t.BindingNames[clusterRole]; ok {
+		return name
+	}
+	return pkgkey.AccessTierBindingName(t.Name, clusterRole)
+}
+
+// DefaultTiers returns the tiers of the customer admin, customer reader and
+// Giant Swarm admin groups. On CAPA, customer admins may also manage
+// AWSClusterRoleIdentities.
+func (a AccessGroups) DefaultTiers(provider string) []AccessTier {
+	customerAdmins := AccessTier{
+		Name:   CustomerAdminsTierName,
+		Groups: a.WriteAllCustomerGroups,
+		ClusterRoles: []string{
+			pkgkey.ClusterAdminClusterRoleName,
+			pkgkey.DefaultReadAllPermissionsName,
+			pkgkey.WriteOrganizationsPermissionsName,
+		},
+		OrganizationNamespaceClusterRoles: []string{pkgkey.ClusterAdminClusterRoleName},
+		ClusterNamespaceClusterRoles:      []string{pkgkey.ClusterAdminClusterRoleName},
+		BindingNames: map[string]string{
+			pkgkey.ClusterAdminClusterRoleName:                pkgkey.WriteAllCustomerGroupClusterRoleBindingName(),
+			pkgkey.DefaultReadAllPermissionsName:              pkgkey.ReadAllCustomerGroupClusterRoleBindingName(),
+			pkgkey.WriteOrganizationsPermissionsName:          pkgkey.WriteOrganizationsCustomerGroupClusterRoleBindingName(),
+			pkgkey.WriteAWSClusterRoleIdentityPermissionsName: pkgkey.WriteAWSClusterRoleIdentityCustomerGroupClusterRoleBindingName(),
+		},
+	}
+	if provider == "capa" {
+		customerAdmins.ClusterRoles = append(customerAdmins.ClusterRoles, pkgkey.WriteAWSClusterRoleIdentityPermissionsName)
+	}
+
+	customerReaders := AccessTier{
+		Name:                              CustomerReadersTierName,
+		Groups:                            a.ReadAllCustomerGroups,
+		ClusterRoles:                      []string{pkgkey.DefaultReadAllPermissionsName},
+		OrganizationNamespaceClusterRoles: []string{pkgkey.DefaultReadAllPermissionsName},
+		BindingNames: map[string]string{
+			pkgkey.DefaultReadAllPermissionsName: pkgkey.ReadAllCustomerGroupClusterRoleBindingName(),
+		},
+	}
+
+	giantswarmAdmins := AccessTier{
+		Name:         GiantswarmAdminsTierName,
+		Groups:       a.WriteAllGiantswarmGroups,
+		ClusterRoles: []string{pkgkey.ClusterAdminClusterRoleName},
+		BindingNames: map[string]string{
+			pkgkey.ClusterAdminClusterRoleName: pkgkey.WriteAllGSGroupClusterRoleBindingName(),
+		},
+	}
+
+	return []AccessTier{customerAdmins, customerReaders, giantswarmAdmins}
+}
+
+// AllTiers returns the default tiers followed by the configured ones.
+func (a AccessGroups) AllTiers(provider string) []AccessTier {
+	return append(a.DefaultTiers(provider), a.Tiers...)
+}
+
+// ValidateTiers returns a message if a tier has no valid name or no ClusterRoles,
+// or if its name is used more than once. Tiers may have no groups, as groups can
+// also be declared using AccessGroup resources.
+func (a *AccessGroups) ValidateTiers() string {
+	names := map[string]bool{
+		CustomerAdminsTierName:   true,
+		CustomerReadersTierName:  true,
+		GiantswarmAdminsTierName: true,
+	}
+
+	for i, tier := range a.Tiers {
+		if errs := validation.IsDNS1123Label(tier.Name); len(errs) > 0 {
+			return fmt.Sprintf("tiers[%d].name %#q is invalid: %s", i, tier.Name, strings.Join(errs, ", "))
+		}
+		if names[tier.Name] {
+			return fmt.Sprintf("tiers[%d].name %#q is already used", i, tier.Name)
+		}
+		names[tier.Name] = true
+
+		if len(tier.ClusterRoles) == 0 && len(tier.OrganizationNamespaceClusterRoles) == 0 && len(tier.ClusterNamespaceClusterRoles) == 0 {
+			return fmt.Sprintf("tier %#q must bind at least one ClusterRole", tier.Name)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,125 @@
+package accessgroup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ValidateTiers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tiers       []AccessTier
+		expectValid bool
+	}{
+		{
+			name: "case 0: valid tier",
+			tiers: []AccessTier{
+				{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}, ClusterRoles: []string{"write-silences"}},
+			},
+			expectValid: true,
+		},
+		{
+			name: "case 1: tier name is not a DNS label",
+			tiers: []AccessTier{
+				{Name: "Platform Team", Groups: []AccessGroup{{Name: "platform"}}, ClusterRoles: []string{"write-silences"}},
+			},
+		},
+		{
+			name: "case 2: tier name of a built-in tier",
+			tiers: []AccessTier{
+				{Name: CustomerAdminsTierName, Groups: []AccessGroup{{Name: "platform"}}, ClusterRoles: []string{"write-silences"}},
+			},
+		},
+		{
+			name: "case 3: tier name used twice",
+			tiers: []AccessTier{
+				{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}, ClusterRoles: []string{"write-silences"}},
+				{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}, ClusterNamespaceClusterRoles: []string{"view"}},
+			},
+		},
+		{
+			name: "case 4: tier without groups, which are declared using AccessGroup resources",
+			tiers: []AccessTier{
+				{Name: "platform", ClusterRoles: []string{"write-silences"}},
+			},
+			expectValid: true,
+		},
+		{
+			name: "case 5: tier without ClusterRoles",
+			tiers: []AccessTier{
+				{Name: "platform", Groups: []AccessGroup{{Name: "platform"}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accessGroups := AccessGroups{Tiers: tc.tiers}
+
+			message := accessGroups.ValidateTiers()
+			if tc.expectValid && message != "" {
+				t.Fatalf("expected tiers to be valid, got %s", message)
+			}
+			if !tc.expectValid && message == "" {
+				t.Fatalf("expected tiers to be invalid")
+			}
+		})
+	}
+}
+
+func Test_DefaultTiers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+
+		expectedClusterRoleBindings []string
+	}{
+		{
+			name:     "case 0: default tiers bind the ClusterRoles of the customer and Giant Swarm groups",
+			provider: "capz",
+			expectedClusterRoleBindings: []string{
+				"write-all-customer-group",
+				"read-all-customer-group",
+				"write-organizations-customer-group",
+				"read-all-customer-group",
+				"write-all-giantswarm-group",
+				"access-tier-platform-write-silences",
+			},
+		},
+		{
+			name:     "case 1: customer admins may manage AWSClusterRoleIdentities on CAPA",
+			provider: "capa",
+			expectedClusterRoleBindings: []string{
+				"write-all-customer-group",
+				"read-all-customer-group",
+				"write-organizations-customer-group",
+				"write-aws-cluster-role-identity-customer-group",
+				"read-all-customer-group",
+				"write-all-giantswarm-group",
+				"access-tier-platform-write-silences",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accessGroups := AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{{Name: "customers"}},
+				Tiers: []AccessTier{
+					{Name: "platform", ClusterRoles: []string{"write-silences"}},
+				},
+			}
+
+			var clusterRoleBindings []string
+			for _, tier := range accessGroups.AllTiers(tc.provider) {
+				for _, clusterRole := range tier.ClusterRoles {
+					clusterRoleBindings = append(clusterRoleBindings, tier.BindingName(clusterRole))
+				}
+			}
+
+			if !reflect.DeepEqual(tc.expectedClusterRoleBindings, clusterRoleBindings) {
+				t.Fatalf("expected ClusterRoleBindings %v, got %v", tc.expectedClusterRoleBindings, clusterRoleBindings)
+			}
+		})
+	}
+}
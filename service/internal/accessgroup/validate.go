@@ -0,0 +1,145 @@
+package accessgroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Report lists the problems found in access groups. Access groups with errors
+// must not be used, warnings point at likely mistakes.
+type Report struct {
+	Errors   []string
+	Warnings []string
+}
+
+type subjectList struct {
+	field  string
+	tier   string
+	groups []AccessGroup
+}
+
+func (a *AccessGroups) subjectLists() []subjectList {
+	lists := []subjectList{
+		{field: "writeAllCustomerGroups", tier: CustomerAdminsTierName, groups: a.WriteAllCustomerGroups},
+		{field: "readAllCustomerGroups", tier: CustomerReadersTierName, groups: a.ReadAllCustomerGroups},
+		{field: "writeAllGiantswarmGroups", tier: GiantswarmAdminsTierName, groups: a.WriteAllGiantswarmGroups},
+	}
+	for i, tier := range a.Tiers {
+		lists = append(lists, subjectList{field: fmt.Sprintf("tiers[%d].groups", i), tier: tier.Name, groups: tier.Groups})
+	}
+	return lists
+}
+
+// Check reports invalid groups, tiers and group prefixes as errors, and groups
+// listed more than once, in several tiers, as both customer admin and reader,
+// or lacking the group prefix of their tier as warnings.
+func (a *AccessGroups) Check() Report {
+	var report Report
+
+	tiers := map[AccessGroup][]string{}
+	var order []AccessGroup
+
+	for _, list := range a.subjectLists() {
+		listed := map[AccessGroup]bool{}
+		for i, group := range list.groups {
+			field := fmt.Sprintf("%s[%d]", list.field, i)
+			if message := validateSubject(field, group); message != "" {
+				report.Errors = append(report.Errors, message)
+				continue
+			}
+
+			subject := AccessGroup{Name: group.Name, Kind: group.subjectKind(), Namespace: group.Namespace}
+			if listed[subject] {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("%s %s %q is listed more than once", field, subject.Kind, subject.Name))
+				continue
+			}
+			listed[subject] = true
+
+			if len(tiers[subject]) == 0 {
+				order = append(order, subject)
+			}
+			tiers[subject] = append(tiers[subject], list.tier)
+		}
+	}
+
+	if message := a.ValidateTiers(); message != "" {
+		report.Errors = append(report.Errors, message)
+	}
+
+	report.Errors = append(report.Errors, a.checkGroupPrefixes()...)
+	if _, rejected := a.Normalize(); len(rejected) > 0 {
+		for _, group := range rejected {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Group %q of tier %s lacks the group prefix %q and is not bound", group.Name, group.Tier, group.Prefix))
+		}
+	}
+
+	for _, subject := range order {
+		subjectTiers := tiers[subject]
+		if len(subjectTiers) < 2 {
+			continue
+		}
+		if contains(subjectTiers, CustomerAdminsTierName) && contains(subjectTiers, CustomerReadersTierName) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s %q is both a customer admin and a customer reader", subject.Kind, subject.Name))
+			continue
+		}
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s %q is in several tiers: %s", subject.Kind, subject.Name, strings.Join(subjectTiers, ", ")))
+	}
+
+	return report
+}
+
+// checkGroupPrefixes returns errors for group prefixes of unknown tiers, and
+// if all Giant Swarm admin groups lack their prefix, as access would be lost.
+func (a *AccessGroups) checkGroupPrefixes() []string {
+	var errors []string
+
+	var tiers []string
+	for tier := range a.GroupPrefixes {
+		tiers = append(tiers, tier)
+	}
+	sort.Strings(tiers)
+
+	for _, tier := range tiers {
+		switch tier {
+		case CustomerAdminsTierName, CustomerReadersTierName, GiantswarmAdminsTierName:
+			continue
+		}
+		if !a.hasTier(tier) {
+			errors = append(errors, fmt.Sprintf("groupPrefixes has a prefix for tier %#q which is not configured", tier))
+		}
+	}
+
+	if ValidateGroups(a.WriteAllGiantswarmGroups) {
+		groups, _ := a.NormalizeGroups(GiantswarmAdminsTierName, a.WriteAllGiantswarmGroups)
+		if !ValidateGroups(groups) {
+			errors = append(errors, fmt.Sprintf("all writeAllGiantswarmGroups lack the group prefix %q", a.GroupPrefixes[GiantswarmAdminsTierName]))
+		}
+	}
+
+	return errors
+}
+
+func (a *AccessGroups) hasTier(name string) bool {
+	for _, tier := range a.Tiers {
+		if tier.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns a message listing all errors, empty if the access groups
+// are valid.
+func (a *AccessGroups) Validate() string {
+	return strings.Join(a.Check().Errors, "; ")
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
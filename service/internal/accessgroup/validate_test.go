@@ -0,0 +1,97 @@
+package accessgroup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Check(t *testing.T) {
+	testCases := []struct {
+		name             string
+		accessGroups     AccessGroups
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name: "case 0: valid access groups",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}},
+				ReadAllCustomerGroups:    []AccessGroup{{Name: "readers"}},
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+			},
+		},
+		{
+			name: "case 1: all malformed entries are reported",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups: []AccessGroup{{Name: ""}, {Name: "admins", Kind: "Team"}},
+				ReadAllCustomerGroups:  []AccessGroup{{Name: "reader", Kind: "ServiceAccount"}},
+			},
+			expectedErrors: []string{
+				"writeAllCustomerGroups[0] has no name",
+				"writeAllCustomerGroups[1] has unknown kind `Team`, must be one of Group, User or ServiceAccount",
+				"readAllCustomerGroups[0] of kind ServiceAccount must have a namespace",
+			},
+		},
+		{
+			name: "case 2: duplicates, readers which are admins and groups in several tiers are warned about",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups:   []AccessGroup{{Name: "admins"}, {Name: "admins", Kind: "Group"}},
+				ReadAllCustomerGroups:    []AccessGroup{{Name: "admins"}},
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+				Tiers: []AccessTier{
+					{Name: "platform", Groups: []AccessGroup{{Name: "giantswarm"}}, ClusterRoles: []string{"write-silences"}},
+				},
+			},
+			expectedWarnings: []string{
+				`writeAllCustomerGroups[1] Group "admins" is listed more than once`,
+				`Group "admins" is both a customer admin and a customer reader`,
+				`Group "giantswarm" is in several tiers: giantswarm-admins, platform`,
+			},
+		},
+		{
+			name: "case 3: groups lacking the prefix of their tier are warned about",
+			accessGroups: AccessGroups{
+				WriteAllCustomerGroups:   []AccessGroup{{Name: "customer:admins"}, {Name: "admins"}, {Name: "admin", Kind: "User"}},
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+				GroupPrefixes:            map[string]string{CustomerAdminsTierName: "customer:"},
+			},
+			expectedWarnings: []string{
+				`Group "admins" of tier customer-admins lacks the group prefix "customer:" and is not bound`,
+			},
+		},
+		{
+			name: "case 4: prefixes of unknown tiers and prefixes rejecting all Giant Swarm admin groups are errors",
+			accessGroups: AccessGroups{
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+				GroupPrefixes:            map[string]string{GiantswarmAdminsTierName: "gs:", "platform": "customer:"},
+			},
+			expectedErrors: []string{
+				"groupPrefixes has a prefix for tier `platform` which is not configured",
+				`all writeAllGiantswarmGroups lack the group prefix "gs:"`,
+			},
+			expectedWarnings: []string{
+				`Group "giantswarm" of tier giantswarm-admins lacks the group prefix "gs:" and is not bound`,
+			},
+		},
+		{
+			name: "case 5: missing prefixes are added if enabled",
+			accessGroups: AccessGroups{
+				WriteAllGiantswarmGroups: []AccessGroup{{Name: "giantswarm"}},
+				GroupPrefixes:            map[string]string{GiantswarmAdminsTierName: "gs:"},
+				AddMissingGroupPrefixes:  true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := tc.accessGroups.Check()
+			if !reflect.DeepEqual(tc.expectedErrors, report.Errors) {
+				t.Fatalf("Expected errors %q, got %q", tc.expectedErrors, report.Errors)
+			}
+			if !reflect.DeepEqual(tc.expectedWarnings, report.Warnings) {
+				t.Fatalf("Expected warnings %q, got %q", tc.expectedWarnings, report.Warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Package apiwatch calls a function shortly after the APIs served by the
+// cluster changed, i.e. after CustomResourceDefinitions or APIServices were
+// added, changed or removed.
+package apiwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// DefaultDelay is the time changes are collected for before OnChange is
+	// called, so that installing many CRDs at once results in a single call.
+	DefaultDelay = 2 * time.Second
+	// maxRetryDelay limits the backoff after OnChange failed, e.g. while an
+	// aggregated API is unavailable.
+	maxRetryDelay = time.Minute
+)
+
+var (
+	// Resources are the resources which change the APIs served by the cluster.
+	Resources = []schema.GroupVersionResource{
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+		{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"},
+	}
+)
+
+type Config struct {
+	DynamicClient dynamic.Interface
+	Logger        micrologger.Logger
+
+	// OnChange is called after the APIs changed, e.g. to update the
+	// ClusterRoles derived from discovery.
+	OnChange func(ctx context.Context) error
+	// Delay defaults to DefaultDelay.
+	Delay time.Duration
+}
+
+// Watch uses informers instead of an operatorkit controller, as that would
+// add finalizers to all CustomResourceDefinitions and APIServices.
+type Watch struct {
+	dynamicClient dynamic.Interface
+	logger        micrologger.Logger
+	onChange      func(ctx context.Context) error
+	delay         time.Duration
+
+	changed chan struct{}
+}
+
+func New(config Config) (*Watch, error) {
+	if config.DynamicClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.DynamicClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.OnChange == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.OnChange must not be empty", config)
+	}
+	if config.Delay == 0 {
+		config.Delay = DefaultDelay
+	}
+
+	w := &Watch{
+		dynamicClient: config.DynamicClient,
+		logger:        config.Logger,
+		onChange:      config.OnChange,
+		delay:         config.Delay,
+
+		changed: make(chan struct{}, 1),
+	}
+
+	return w, nil
+}
+
+// Run watches the APIs until the context is done. OnChange is called once
+// Delay after the first change, covering all changes made in the meantime.
+// The objects existing at start are not considered changes. Failed calls are
+// retried with an increasing delay.
+func (w *Watch) Run(ctx context.Context) {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicClient, 0)
+	defer factory.Shutdown()
+
+	handler := cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				w.notify()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if apiChanged(oldObj, newObj) {
+				w.notify()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			w.notify()
+		},
+	}
+
+	for _, resource := range Resources {
+		_, err := factory.ForResource(resource).Informer().AddEventHandler(handler)
+		if err != nil {
+			w.logger.Errorf(ctx, err, "Could not watch %s for API changes", resource.GroupResource())
+			return
+		}
+	}
+	factory.Start(ctx.Done())
+
+	var timer <-chan time.Time
+	retryDelay := w.delay
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.changed:
+			if timer == nil {
+				timer = time.After(w.delay)
+			}
+		case <-timer:
+			err := w.onChange(ctx)
+			if err != nil {
+				w.logger.Errorf(ctx, err, "Could not update after API changes, retrying in %s", retryDelay)
+				timer = time.After(retryDelay)
+				retryDelay = min(2*retryDelay, maxRetryDelay)
+				continue
+			}
+			timer = nil
+			retryDelay = w.delay
+		}
+	}
+}
+
+func (w *Watch) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// apiChanged returns whether an update may change the served APIs, which is
+// the case if the spec or the status changed, e.g. a CRD got established or
+// an APIService became available. Metadata changes are ignored.
+func apiChanged(oldObj, newObj interface{}) bool {
+	oldUnstructured, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	newUnstructured, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+
+	if oldUnstructured.GetGeneration() != newUnstructured.GetGeneration() {
+		return true
+	}
+
+	return !equality.Semantic.DeepEqual(oldUnstructured.Object["status"], newUnstructured.Object["status"])
+}
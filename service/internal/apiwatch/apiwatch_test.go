@@ -0,0 +1,129 @@
+package apiwatch
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func Test_Run(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      []string
+		change        func(ctx context.Context, t *testing.T, client *dynamicfake.FakeDynamicClient)
+		expectedCalls int32
+	}{
+		{
+			name:          "case 0: existing CRDs are no changes",
+			existing:      []string{"apps.application.giantswarm.io"},
+			expectedCalls: 0,
+		},
+		{
+			name: "case 1: CRDs installed at once result in a single call",
+			change: func(ctx context.Context, t *testing.T, client *dynamicfake.FakeDynamicClient) {
+				for _, name := range []string{"apps.application.giantswarm.io", "catalogs.application.giantswarm.io"} {
+					_, err := client.Resource(Resources[0]).Create(ctx, newObject("CustomResourceDefinition", name), metav1.CreateOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:     "case 2: a removed APIService is a change",
+			existing: []string{"v1beta1.metrics.k8s.io"},
+			change: func(ctx context.Context, t *testing.T, client *dynamicfake.FakeDynamicClient) {
+				err := client.Resource(Resources[1]).Delete(ctx, "v1beta1.metrics.k8s.io", metav1.DeleteOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:     "case 3: metadata changes are no changes",
+			existing: []string{"apps.application.giantswarm.io"},
+			change: func(ctx context.Context, t *testing.T, client *dynamicfake.FakeDynamicClient) {
+				crd := newObject("CustomResourceDefinition", "apps.application.giantswarm.io")
+				crd.SetLabels(map[string]string{"app": "example"})
+				_, err := client.Resource(Resources[0]).Update(ctx, crd, metav1.UpdateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objects []runtime.Object
+			for _, name := range tc.existing {
+				kind := "CustomResourceDefinition"
+				if name == "v1beta1.metrics.k8s.io" {
+					kind = "APIService"
+				}
+				objects = append(objects, newObject(kind, name))
+			}
+
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				Resources[0]: "CustomResourceDefinitionList",
+				Resources[1]: "APIServiceList",
+			}, objects...)
+
+			var calls atomic.Int32
+			w, err := New(Config{
+				DynamicClient: client,
+				Logger:        microloggertest.New(),
+				OnChange: func(ctx context.Context) error {
+					calls.Add(1)
+					return nil
+				},
+				Delay: 200 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go w.Run(ctx)
+			// wait for the informers to list the existing objects
+			time.Sleep(100 * time.Millisecond)
+
+			if tc.change != nil {
+				tc.change(ctx, t, client)
+			}
+			time.Sleep(500 * time.Millisecond)
+
+			if calls.Load() != tc.expectedCalls {
+				t.Fatalf("Expected %d calls, got %d", tc.expectedCalls, calls.Load())
+			}
+		})
+	}
+}
+
+func newObject(kind, name string) *unstructured.Unstructured {
+	group := "apiextensions.k8s.io/v1"
+	if kind == "APIService" {
+		group = "apiregistration.k8s.io/v1"
+	}
+
+	o := &unstructured.Unstructured{}
+	o.SetAPIVersion(group)
+	o.SetKind(kind)
+	o.SetName(name)
+	o.SetGeneration(1)
+
+	return o
+}
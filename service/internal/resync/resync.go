@@ -0,0 +1,61 @@
+// Package resync requeues the objects watched by operatorkit controllers right
+// away, e.g. when configuration the controllers depend on changed. Objects are
+// annotated, so that the watches of the controllers enqueue them and they are
+// reconciled by the workers of the controllers like any other change.
+package resync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+// Enqueue sets the resync annotation of the given objects to the current time.
+// Only the names of the objects are used, objects which no longer exist are
+// skipped. All objects are annotated even if some fail, the first error is
+// returned.
+func Enqueue(ctx context.Context, ctrlClient client.Client, objects ...client.Object) error {
+	patch, err := newPatch(time.Now())
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var first error
+	for _, obj := range objects {
+		err = ctrlClient.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil && first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return microerror.Mask(first)
+	}
+
+	return nil
+}
+
+func newPatch(now time.Time) ([]byte, error) {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				annotation.Resync: now.UTC().Format(time.RFC3339Nano),
+			},
+		},
+	}
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,64 @@
+package resync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+func Test_Enqueue(t *testing.T) {
+	ctx := context.Background()
+	ctrlClient := clientfake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme", Annotations: map[string]string{"example": "kept"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-other"}},
+		).
+		Build()
+
+	var objects []client.Object
+	for _, name := range []string{"org-acme", "org-deleted", "org-other"} {
+		namespace := &corev1.Namespace{}
+		namespace.Name = name
+		objects = append(objects, namespace)
+	}
+
+	before := time.Now().Add(-time.Second)
+	err := Enqueue(ctx, ctrlClient, objects...)
+	if err != nil {
+		t.Fatalf("Expected success, got error %v", err)
+	}
+
+	for _, name := range []string{"org-acme", "org-other"} {
+		namespace := &corev1.Namespace{}
+		err = ctrlClient.Get(ctx, client.ObjectKey{Name: name}, namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		value, err := time.Parse(time.RFC3339Nano, namespace.Annotations[annotation.Resync])
+		if err != nil {
+			t.Fatalf("Expected namespace %s to be annotated with the time, got error %v", name, err)
+		}
+		if value.Before(before) {
+			t.Fatalf("Expected namespace %s to be annotated with the current time, got %s", name, value)
+		}
+	}
+
+	namespace := &corev1.Namespace{}
+	err = ctrlClient.Get(ctx, client.ObjectKey{Name: "org-acme"}, namespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespace.Annotations["example"] != "kept" {
+		t.Fatalf("Expected other annotations to be kept, got %v", namespace.Annotations)
+	}
+}
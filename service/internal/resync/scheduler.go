@@ -0,0 +1,147 @@
+package resync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultRetryDelay is the delay before requeueing an object is retried
+	// the first time, it is doubled after every failure.
+	DefaultRetryDelay = time.Second
+	// maxRetryDelay limits the backoff after requeueing an object failed.
+	maxRetryDelay = time.Minute
+)
+
+type SchedulerConfig struct {
+	CtrlClient client.Client
+	Logger     micrologger.Logger
+
+	// NewObjectFunc returns an empty object of the kind requeued.
+	NewObjectFunc func() client.Object
+	// RetryDelay defaults to DefaultRetryDelay.
+	RetryDelay time.Duration
+}
+
+// Scheduler requeues objects at a given time, e.g. when a time based condition
+// changes, which the controllers would otherwise only pick up with the next
+// resync. A single Scheduler is meant to be shared by everything scheduling
+// objects of a controller, so that every object is requeued once.
+type Scheduler struct {
+	ctrlClient    client.Client
+	logger        micrologger.Logger
+	newObjectFunc func() client.Object
+	retryDelay    time.Duration
+
+	mutex     sync.Mutex
+	scheduled map[string]*schedule
+}
+
+type schedule struct {
+	at    time.Time
+	timer *time.Timer
+}
+
+func NewScheduler(config SchedulerConfig) (*Scheduler, error) {
+	if config.CtrlClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.CtrlClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.NewObjectFunc == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.NewObjectFunc must not be empty", config)
+	}
+	if config.RetryDelay == 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	s := &Scheduler{
+		ctrlClient:    config.CtrlClient,
+		logger:        config.Logger,
+		newObjectFunc: config.NewObjectFunc,
+		retryDelay:    config.RetryDelay,
+
+		scheduled: map[string]*schedule{},
+	}
+
+	return s, nil
+}
+
+// Schedule requeues the cluster scoped object with the given name at the given
+// time, replacing the time scheduled before. Failures are retried with an
+// increasing delay until the object is requeued or the schedule is cancelled.
+func (s *Scheduler) Schedule(name string, at time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if current, ok := s.scheduled[name]; ok {
+		if current.at.Equal(at) {
+			return
+		}
+		current.timer.Stop()
+	}
+
+	scheduled := &schedule{at: at}
+	scheduled.timer = time.AfterFunc(time.Until(at), func() {
+		s.enqueue(name, scheduled, s.retryDelay)
+	})
+	s.scheduled[name] = scheduled
+}
+
+// Cancel drops the time scheduled for the object with the given name, if any.
+func (s *Scheduler) Cancel(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if current, ok := s.scheduled[name]; ok {
+		current.timer.Stop()
+		delete(s.scheduled, name)
+	}
+}
+
+// Scheduled returns the time the object with the given name is requeued at,
+// if any.
+func (s *Scheduler) Scheduled(name string) (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, ok := s.scheduled[name]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return current.at, true
+}
+
+func (s *Scheduler) enqueue(name string, scheduled *schedule, retryDelay time.Duration) {
+	ctx := context.Background()
+
+	obj := s.newObjectFunc()
+	obj.SetName(name)
+	err := Enqueue(ctx, s.ctrlClient, obj)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the schedule was cancelled or replaced in the meantime
+	if s.scheduled[name] != scheduled {
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf(ctx, err, "Could not requeue %s, retrying in %s", name, retryDelay)
+		next := min(2*retryDelay, maxRetryDelay)
+		scheduled.timer = time.AfterFunc(retryDelay, func() {
+			s.enqueue(name, scheduled, next)
+		})
+		return
+	}
+
+	delete(s.scheduled, name)
+}
@@ -0,0 +1,111 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+func Test_Scheduler(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failures int32
+		schedule func(s *Scheduler)
+
+		expectedRequeue bool
+	}{
+		{
+			name: "case 0: objects are requeued at the scheduled time",
+			schedule: func(s *Scheduler) {
+				s.Schedule("org-acme", time.Now().Add(50*time.Millisecond))
+			},
+			expectedRequeue: true,
+		},
+		{
+			name: "case 1: cancelled objects are not requeued",
+			schedule: func(s *Scheduler) {
+				s.Schedule("org-acme", time.Now().Add(50*time.Millisecond))
+				s.Cancel("org-acme")
+			},
+			expectedRequeue: false,
+		},
+		{
+			name: "case 2: scheduling again replaces the scheduled time",
+			schedule: func(s *Scheduler) {
+				s.Schedule("org-acme", time.Now().Add(50*time.Millisecond))
+				s.Schedule("org-acme", time.Now().Add(time.Hour))
+			},
+			expectedRequeue: false,
+		},
+		{
+			name:     "case 3: failures are retried",
+			failures: 2,
+			schedule: func(s *Scheduler) {
+				s.Schedule("org-acme", time.Now().Add(50*time.Millisecond))
+			},
+			expectedRequeue: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var patches atomic.Int32
+			ctrlClient := clientfake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "org-acme"}}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if patches.Add(1) <= tc.failures {
+							return errors.New("unavailable")
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+
+			s, err := NewScheduler(SchedulerConfig{
+				CtrlClient: ctrlClient,
+				Logger:     microloggertest.New(),
+				NewObjectFunc: func() client.Object {
+					return new(corev1.Namespace)
+				},
+				RetryDelay: 20 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Cancel("org-acme")
+
+			tc.schedule(s)
+			time.Sleep(300 * time.Millisecond)
+
+			namespace := &corev1.Namespace{}
+			err = ctrlClient.Get(ctx, client.ObjectKey{Name: "org-acme"}, namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, requeued := namespace.Annotations[annotation.Resync]
+			if requeued != tc.expectedRequeue {
+				t.Fatalf("Expected requeue %t, got %t", tc.expectedRequeue, requeued)
+			}
+			_, scheduled := s.Scheduled("org-acme")
+			if requeued && scheduled {
+				t.Fatalf("Expected requeued object to be no longer scheduled")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package trigger
+
+import (
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objects holds the latest state of the queued objects, including deleted
+// ones which are no longer known to the informer.
+type objects struct {
+	mutex   sync.Mutex
+	objects map[string]client.Object
+}
+
+func newObjects() *objects {
+	return &objects{
+		objects: map[string]client.Object{},
+	}
+}
+
+func (o *objects) get(key string) (client.Object, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	obj, ok := o.objects[key]
+	return obj, ok
+}
+
+func (o *objects) set(key string, obj client.Object) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.objects[key] = obj
+}
+
+// delete drops the object unless it changed again in the meantime.
+func (o *objects) delete(key string, obj client.Object) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.objects[key] == obj {
+		delete(o.objects, key)
+	}
+}
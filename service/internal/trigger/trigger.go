@@ -0,0 +1,197 @@
+// Package trigger calls a function for objects added, changed or deleted in a
+// shared informer, e.g. to requeue the objects of a controller depending on
+// them. Informers are used instead of operatorkit controllers, as these add
+// finalizers to the objects they watch and thereby require write access.
+package trigger
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+// Informer is implemented by client-go informers and the informers of a
+// controller-runtime cache.
+type Informer interface {
+	AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)
+	RemoveEventHandler(handle cache.ResourceEventHandlerRegistration) error
+}
+
+// Informers provides informers shared by all triggers, e.g. a
+// controller-runtime cache, which is started by the service.
+type Informers interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...ctrlcache.InformerGetOption) (ctrlcache.Informer, error)
+}
+
+type Config struct {
+	Informer Informer
+	Logger   micrologger.Logger
+
+	// Name describes the trigger in logs, e.g. rolebindingtemplate-organization.
+	Name string
+	// OnChange is called with the latest state of objects added, changed or
+	// deleted after the informer listed the existing ones.
+	OnChange func(ctx context.Context, obj client.Object) error
+}
+
+// Trigger calls OnChange for every changed object from a rate limited queue,
+// so that changes of the same object are coalesced and failed calls are
+// retried with an increasing delay.
+type Trigger struct {
+	informer Informer
+	logger   micrologger.Logger
+	name     string
+	onChange func(ctx context.Context, obj client.Object) error
+
+	queue   workqueue.TypedRateLimitingInterface[string]
+	objects *objects
+}
+
+func New(config Config) (*Trigger, error) {
+	if config.Informer == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Informer must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Name must not be empty", config)
+	}
+	if config.OnChange == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.OnChange must not be empty", config)
+	}
+
+	t := &Trigger{
+		informer: config.Informer,
+		logger:   config.Logger,
+		name:     config.Name,
+		onChange: config.OnChange,
+
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: config.Name},
+		),
+		objects: newObjects(),
+	}
+
+	return t, nil
+}
+
+// Run calls OnChange for changed objects until the context is done. The
+// informer is started by its owner.
+func (t *Trigger) Run(ctx context.Context) {
+	handler := cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				t.add(obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if changed(oldObj, newObj) {
+				t.add(newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			t.add(obj)
+		},
+	}
+
+	registration, err := t.informer.AddEventHandler(handler)
+	if err != nil {
+		t.logger.Errorf(ctx, err, "Could not watch objects for trigger %s", t.name)
+		return
+	}
+	defer func() {
+		_ = t.informer.RemoveEventHandler(registration)
+	}()
+
+	go func() {
+		<-ctx.Done()
+		t.queue.ShutDown()
+	}()
+
+	for t.process(ctx) {
+	}
+}
+
+func (t *Trigger) add(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+
+	key := client.ObjectKeyFromObject(o).String()
+	t.objects.set(key, o)
+	t.queue.Add(key)
+}
+
+func (t *Trigger) process(ctx context.Context) bool {
+	key, shutdown := t.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer t.queue.Done(key)
+
+	obj, ok := t.objects.get(key)
+	if !ok {
+		t.queue.Forget(key)
+		return true
+	}
+
+	err := t.onChange(ctx, obj)
+	if err != nil {
+		t.logger.Errorf(ctx, err, "Trigger %s could not handle the change of %s, retrying", t.name, key)
+		t.queue.AddRateLimited(key)
+		return true
+	}
+
+	t.queue.Forget(key)
+	t.objects.delete(key, obj)
+
+	return true
+}
+
+// changed returns whether an update is more than a periodic resync of the
+// informer or setting the resync annotation, which would otherwise requeue
+// everything depending on an object whenever the object itself is requeued.
+func changed(oldObj, newObj interface{}) bool {
+	oldObject, ok := oldObj.(client.Object)
+	if !ok {
+		return true
+	}
+	newObject, ok := newObj.(client.Object)
+	if !ok {
+		return true
+	}
+	if oldObject.GetResourceVersion() == newObject.GetResourceVersion() {
+		return false
+	}
+
+	return !reflect.DeepEqual(withoutVolatileFields(oldObject), withoutVolatileFields(newObject))
+}
+
+func withoutVolatileFields(obj client.Object) client.Object {
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, annotation.Resync)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	return obj
+}
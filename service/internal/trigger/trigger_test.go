@@ -0,0 +1,141 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/rbac-operator/pkg/annotation"
+)
+
+func Test_Trigger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failures int
+		change   func(ctx context.Context, t *testing.T, clientset *clientgofake.Clientset)
+
+		expectedCalls []string
+	}{
+		{
+			name:          "case 0: existing objects are no changes",
+			expectedCalls: nil,
+		},
+		{
+			name: "case 1: added, changed and deleted objects are changes",
+			change: func(ctx context.Context, t *testing.T, clientset *clientgofake.Clientset) {
+				_, err := clientset.CoreV1().Namespaces().Create(ctx, newNamespace("org-new", nil), metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				namespace := newNamespace("org-acme", map[string]string{"example": "changed"})
+				namespace.ResourceVersion = "2"
+				_, err = clientset.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = clientset.CoreV1().Namespaces().Delete(ctx, "org-other", metav1.DeleteOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: []string{"org-acme", "org-new", "org-other"},
+		},
+		{
+			name: "case 2: setting the resync annotation is no change",
+			change: func(ctx context.Context, t *testing.T, clientset *clientgofake.Clientset) {
+				namespace := newNamespace("org-acme", map[string]string{annotation.Resync: time.Now().Format(time.RFC3339Nano)})
+				namespace.ResourceVersion = "2"
+				_, err := clientset.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: nil,
+		},
+		{
+			name:     "case 3: failures are retried",
+			failures: 2,
+			change: func(ctx context.Context, t *testing.T, clientset *clientgofake.Clientset) {
+				_, err := clientset.CoreV1().Namespaces().Create(ctx, newNamespace("org-new", nil), metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: []string{"org-new", "org-new", "org-new"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			clientset := clientgofake.NewSimpleClientset(
+				newNamespace("org-acme", nil),
+				newNamespace("org-other", nil),
+			)
+			factory := informers.NewSharedInformerFactory(clientset, 0)
+			informer := factory.Core().V1().Namespaces().Informer()
+
+			var mutex sync.Mutex
+			var calls []string
+			trigger, err := New(Config{
+				Informer: informer,
+				Logger:   microloggertest.New(),
+				Name:     "test",
+				OnChange: func(ctx context.Context, obj client.Object) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					calls = append(calls, obj.GetName())
+					if len(calls) <= tc.failures {
+						return errors.New("unavailable")
+					}
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go trigger.Run(ctx)
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+			// wait for the handler to receive the existing objects
+			time.Sleep(100 * time.Millisecond)
+
+			if tc.change != nil {
+				tc.change(ctx, t, clientset)
+			}
+			time.Sleep(500 * time.Millisecond)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			sort.Strings(calls)
+			if !reflect.DeepEqual(tc.expectedCalls, calls) {
+				t.Fatalf("Expected calls %v, got %v", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func newNamespace(name string, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Annotations:     annotations,
+			ResourceVersion: "1",
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package render
+
+import (
+	"os"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+const (
+	flagAccessGroups        = "access-groups"
+	flagFilename            = "filename"
+	flagProtectedNamespaces = "protected-namespaces"
+	flagProvider            = "provider"
+)
+
+// NewCommand returns the render command, which prints the RBAC objects the
+// operator would create for the given access groups, protected namespaces,
+// provider and manifests.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "render",
+		Short: "Print the RBAC resources the operator would create, without a cluster.",
+		Long: `Print the RBAC resources the operator would create, without a cluster.
+
+The access groups file has the same structure as the accessGroups section of
+the operator configuration. The protected namespaces file contains the
+protectedNamespaces Helm value, org-giantswarm is protected when it is not
+given. Manifests may contain Namespaces, Organizations, RoleTemplates,
+RoleBindingTemplates, ClusterRoleBindingTemplates and any pre-existing RBAC
+resources. The default namespace and the cluster-admin
+ClusterRole are added when missing.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runE,
+	}
+
+	c.Flags().String(flagAccessGroups, "", "Path to the access groups file.")
+	c.Flags().StringArrayP(flagFilename, "f", nil, "Path to a manifest file. Can be repeated.")
+	c.Flags().String(flagProtectedNamespaces, "", "Path to a file with the protectedNamespaces Helm value, e.g. the Helm values file.")
+	c.Flags().String(flagProvider, "", "Infrastructure provider (e.g. capa, capz, capv).")
+
+	return c
+}
+
+func runE(cmd *cobra.Command, args []string) error {
+	accessGroupsFile, err := cmd.Flags().GetString(flagAccessGroups)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	filenames, err := cmd.Flags().GetStringArray(flagFilename)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	protectedNamespacesFile, err := cmd.Flags().GetString(flagProtectedNamespaces)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	provider, err := cmd.Flags().GetString(flagProvider)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if accessGroupsFile == "" {
+		return microerror.Maskf(invalidConfigError, "--%s must not be empty", flagAccessGroups)
+	}
+
+	var accessGroups accessgroup.AccessGroups
+	{
+		v := viper.New()
+		v.SetConfigFile(accessGroupsFile)
+
+		err = v.ReadInConfig()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = v.Unmarshal(&accessGroups)
+		if err != nil {
+			return microerror.Maskf(invalidConfigError, "access groups could not be parsed: %v", err)
+		}
+	}
+
+	// Like the operator, the default policy applies when no namespaces are
+	// protected.
+	var protectionPolicy protection.Policy
+	if protectedNamespacesFile != "" {
+		v := viper.New()
+		v.SetConfigFile(protectedNamespacesFile)
+
+		err = v.ReadInConfig()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = v.UnmarshalKey("protectedNamespaces", &protectionPolicy.Namespaces)
+		if err != nil {
+			return microerror.Maskf(invalidConfigError, "protected namespaces could not be parsed: %v", err)
+		}
+
+		err = protectionPolicy.Validate()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	// Logs go to stderr so that stdout only contains the rendered resources.
+	var logger micrologger.Logger
+	{
+		c := micrologger.Config{
+			IOWriter: cmd.ErrOrStderr(),
+		}
+
+		logger, err = micrologger.New(c)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var renderer *Renderer
+	{
+		c := Config{
+			Logger: logger,
+
+			AccessGroups: accessGroups,
+			Protection:   protectionPolicy,
+			Provider:     provider,
+		}
+
+		renderer, err = New(c)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var objects []runtime.Object
+	for _, filename := range filenames {
+		f, err := os.Open(filename)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		decoded, err := renderer.Decode(f)
+		_ = f.Close()
+		if err != nil {
+			return microerror.Maskf(invalidManifestError, "%s: %v", filename, err)
+		}
+
+		objects = append(objects, decoded...)
+	}
+
+	rendered, err := renderer.Render(cmd.Context(), objects)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = Write(cmd.OutOrStdout(), rendered)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
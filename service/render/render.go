@@ -0,0 +1,527 @@
+// Package render runs the operator's resources against fake clients in order
+// to show which RBAC objects a configuration produces, without a cluster.
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
+	"github.com/giantswarm/k8sclient/v8/pkg/k8sclienttest"
+	"github.com/giantswarm/k8smetadata/pkg/label"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/v7/pkg/resource"
+	security "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	pkgkey "github.com/giantswarm/rbac-operator/pkg/key"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/controller/accessgroup/resource/declaration"
+	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace/resource/clusternamespaceresources"
+	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace/resource/rbacappoperator"
+	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace/resource/rbaccleaner"
+	"github.com/giantswarm/rbac-operator/service/controller/clusterrolebindingtemplate/resource/clusterrolebinding"
+	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace"
+	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/automation"
+	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/externalresources"
+	"github.com/giantswarm/rbac-operator/service/controller/rbac/resource/namespaceauth"
+	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate/resource/rolebinding"
+	"github.com/giantswarm/rbac-operator/service/controller/roletemplate/resource/role"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	AccessGroups accessgroup.AccessGroups
+	Protection   protection.Policy
+	Provider     string
+}
+
+type Renderer struct {
+	logger micrologger.Logger
+	scheme *runtime.Scheme
+
+	accessGroups *accessgroup.Store
+	protection   protection.Policy
+	provider     string
+}
+
+func New(config Config) (*Renderer, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if !config.AccessGroups.HasValidWriteAllGiantswarmAdminGroups() {
+		return nil, microerror.Maskf(invalidConfigError, "Giantswarm Write All Admin groups must not be empty")
+	}
+	if message := config.AccessGroups.Validate(); message != "" {
+		return nil, microerror.Maskf(invalidConfigError, "access groups are invalid: %s", message)
+	}
+
+	scheme := runtime.NewScheme()
+	{
+		schemeBuilder := runtime.SchemeBuilder{
+			clientgoscheme.AddToScheme,
+			security.AddToScheme,
+			v1alpha1.AddToScheme,
+		}
+
+		err := schemeBuilder.AddToScheme(scheme)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	r := &Renderer{
+		logger: config.Logger,
+		scheme: scheme,
+
+		accessGroups: accessgroup.NewStore(config.AccessGroups),
+		protection:   config.Protection.OrDefault(),
+		provider:     config.Provider,
+	}
+
+	return r, nil
+}
+
+// Decode reads a stream of YAML or JSON documents and returns the objects it
+// contains. Empty documents are skipped.
+func (r *Renderer) Decode(reader io.Reader) ([]runtime.Object, error) {
+	deserializer := serializer.NewCodecFactory(r.scheme).UniversalDeserializer()
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+
+	var objects []runtime.Object
+	for {
+		var raw runtime.RawExtension
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, microerror.Maskf(invalidManifestError, "%v", err)
+		}
+
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, microerror.Maskf(invalidManifestError, "%v", err)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// Render seeds fake clients with the given objects, adds the access tiers granted
+// by the AccessGroups among them to the access groups and runs the resources of
+// the default namespace, namespace, cluster namespace and template
+// controllers against them, in the order the operator would reconcile them.
+// It returns all Roles, RoleBindings, ClusterRoles and ClusterRoleBindings
+// that exist afterwards.
+func (r *Renderer) Render(ctx context.Context, objects []runtime.Object) ([]client.Object, error) {
+	k8sClient, err := r.newK8sClient(objects)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	{
+		declarations, err := declaration.ListDeclarations(ctx, k8sClient.CtrlClient(), "")
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		r.accessGroups.SetDeclarations(declarations)
+	}
+
+	{
+		c := defaultnamespace.DefaultNamespaceConfig{
+			K8sClient:    k8sClient,
+			Logger:       r.logger,
+			AccessGroups: r.accessGroups,
+			Protection:   r.protection,
+			Provider:     r.provider,
+		}
+
+		defaultNamespace, err := defaultnamespace.NewDefaultNamespace(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		err = defaultNamespace.EnsureResourcesCreated(ctx)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	err = r.ensureNamespaces(ctx, k8sClient)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	err = r.ensureTemplates(ctx, k8sClient)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	rendered, err := listRBAC(ctx, k8sClient)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return rendered, nil
+}
+
+// Write prints the given objects to w as a stream of YAML documents.
+func Write(w io.Writer, objects []client.Object) error {
+	for i, obj := range objects {
+		if i > 0 {
+			_, err := fmt.Fprintln(w, "---")
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		_, err = w.Write(data)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// newK8sClient splits the given objects between the client-go and the
+// controller-runtime fake clients. Custom resources go to the
+// controller-runtime client, which is the one the operator reads them with. The default namespace and the cluster-admin
+// ClusterRole are added when missing, since the default namespace resources
+// depend on them.
+func (r *Renderer) newK8sClient(objects []runtime.Object) (k8sclient.Interface, error) {
+	var hasDefaultNamespace, hasClusterAdmin bool
+
+	var k8sObjects, ctrlObjects []runtime.Object
+	for _, obj := range objects {
+		obj = obj.DeepCopyObject()
+
+		switch o := obj.(type) {
+		case *corev1.Namespace:
+			if o.Name == pkgkey.DefaultNamespaceName {
+				hasDefaultNamespace = true
+			}
+		case *rbacv1.ClusterRole:
+			if o.Name == pkgkey.ClusterAdminClusterRoleName {
+				hasClusterAdmin = true
+			}
+		case *security.Organization:
+			// The organization namespace is usually set by organization-operator.
+			if o.Status.Namespace == "" {
+				o.Status.Namespace = fmt.Sprintf("org-%s", o.Name)
+			}
+		}
+
+		kinds, _, err := r.scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, microerror.Maskf(invalidManifestError, "%v", err)
+		}
+
+		switch kinds[0].Group {
+		case security.SchemeGroupVersion.Group, v1alpha1.GroupVersion.Group:
+			ctrlObjects = append(ctrlObjects, obj)
+		default:
+			k8sObjects = append(k8sObjects, obj)
+		}
+	}
+
+	if !hasDefaultNamespace {
+		k8sObjects = append(k8sObjects, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   pkgkey.DefaultNamespaceName,
+				Labels: map[string]string{pkgkey.NameLabel: pkgkey.DefaultNamespaceName},
+			},
+		})
+	}
+	if !hasClusterAdmin {
+		k8sObjects = append(k8sObjects, &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   pkgkey.ClusterAdminClusterRoleName,
+				Labels: map[string]string{"kubernetes.io/bootstrapping": "rbac-defaults"},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"*"},
+					Resources: []string{"*"},
+					Verbs:     []string{"*"},
+				},
+				{
+					NonResourceURLs: []string{"*"},
+					Verbs:           []string{"*"},
+				},
+			},
+		})
+	}
+
+	k8sClient := k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+		CtrlClient: clientfake.NewClientBuilder().
+			WithScheme(r.scheme).
+			WithRuntimeObjects(ctrlObjects...).
+			WithStatusSubresource(
+				&v1alpha1.RoleBindingTemplate{},
+				&v1alpha1.ClusterRoleBindingTemplate{},
+				&v1alpha1.RoleTemplate{},
+				&v1alpha1.AccessGroup{},
+			).
+			Build(),
+		K8sClient: clientgofake.NewSimpleClientset(k8sObjects...),
+	})
+
+	return k8sClient, nil
+}
+
+// ensureNamespaces runs the namespace resources against every namespace and
+// the cluster namespace resources against namespaces belonging to a cluster.
+func (r *Renderer) ensureNamespaces(ctx context.Context, k8sClient k8sclient.Interface) error {
+	var namespaceResources []resource.Interface
+	{
+		externalResourcesResource, err := externalresources.New(externalresources.Config{K8sClient: k8sClient, Logger: r.logger, Protection: r.protection})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		automationResource, err := automation.New(automation.Config{K8sClient: k8sClient, Logger: r.logger})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		c := namespaceauth.Config{
+			K8sClient: k8sClient,
+			Logger:    r.logger,
+
+			AccessGroups: r.accessGroups,
+			Protection:   r.protection,
+		}
+
+		namespaceAuthResource, err := namespaceauth.New(c)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		namespaceResources = []resource.Interface{
+			automationResource,
+			namespaceAuthResource,
+			externalResourcesResource,
+		}
+	}
+
+	var clusterNamespaceResources []resource.Interface
+	{
+		clusterNamespaceResourcesResource, err := clusternamespaceresources.New(clusternamespaceresources.Config{K8sClient: k8sClient, Logger: r.logger, Protection: r.protection})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		rbacCleanerResource, err := rbaccleaner.New(rbaccleaner.Config{K8sClient: k8sClient, Logger: r.logger})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		rbacAppOperatorResource, err := rbacappoperator.New(rbacappoperator.Config{K8sClient: k8sClient, Logger: r.logger, Protection: r.protection})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		clusterNamespaceResources = []resource.Interface{
+			clusterNamespaceResourcesResource,
+			rbacAppOperatorResource,
+			rbacCleanerResource,
+		}
+	}
+
+	namespaces, err := k8sClient.K8sClient().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+
+		for _, res := range namespaceResources {
+			err = res.EnsureCreated(ctx, namespace)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		if !isClusterNamespace(namespace) {
+			continue
+		}
+
+		for _, res := range clusterNamespaceResources {
+			err = res.EnsureCreated(ctx, namespace)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureTemplates runs the template resources against every RoleTemplate,
+// RoleBindingTemplate and ClusterRoleBindingTemplate, including the ones
+// created by the default namespace resources.
+func (r *Renderer) ensureTemplates(ctx context.Context, k8sClient k8sclient.Interface) error {
+	roleResource, err := role.New(role.Config{K8sClient: k8sClient, Logger: r.logger})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	roleBindingResource, err := rolebinding.New(rolebinding.Config{K8sClient: k8sClient, Logger: r.logger, AccessGroups: r.accessGroups, Protection: r.protection})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	clusterRoleBindingResource, err := clusterrolebinding.New(clusterrolebinding.Config{K8sClient: k8sClient, Logger: r.logger, Protection: r.protection})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	roleTemplates := &v1alpha1.RoleTemplateList{}
+	err = k8sClient.CtrlClient().List(ctx, roleTemplates)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range roleTemplates.Items {
+		err = roleResource.EnsureCreated(ctx, &roleTemplates.Items[i])
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	roleBindingTemplates := &v1alpha1.RoleBindingTemplateList{}
+	err = k8sClient.CtrlClient().List(ctx, roleBindingTemplates)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range roleBindingTemplates.Items {
+		err = roleBindingResource.EnsureCreated(ctx, &roleBindingTemplates.Items[i])
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	clusterRoleBindingTemplates := &v1alpha1.ClusterRoleBindingTemplateList{}
+	err = k8sClient.CtrlClient().List(ctx, clusterRoleBindingTemplates)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for i := range clusterRoleBindingTemplates.Items {
+		err = clusterRoleBindingResource.EnsureCreated(ctx, &clusterRoleBindingTemplates.Items[i])
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// listRBAC returns all RBAC objects known to the fake clientset, grouped by
+// kind and sorted by namespace and name so that the output is stable.
+func listRBAC(ctx context.Context, k8sClient k8sclient.Interface) ([]client.Object, error) {
+	rbacClient := k8sClient.K8sClient().RbacV1()
+
+	var rendered []client.Object
+
+	clusterRoles, err := rbacClient.ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	var objects []client.Object
+	for i := range clusterRoles.Items {
+		clusterRoles.Items[i].TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"}
+		objects = append(objects, &clusterRoles.Items[i])
+	}
+	rendered = append(rendered, sortObjects(objects)...)
+
+	clusterRoleBindings, err := rbacClient.ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	objects = nil
+	for i := range clusterRoleBindings.Items {
+		clusterRoleBindings.Items[i].TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"}
+		objects = append(objects, &clusterRoleBindings.Items[i])
+	}
+	rendered = append(rendered, sortObjects(objects)...)
+
+	roles, err := rbacClient.Roles(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	objects = nil
+	for i := range roles.Items {
+		roles.Items[i].TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"}
+		objects = append(objects, &roles.Items[i])
+	}
+	rendered = append(rendered, sortObjects(objects)...)
+
+	roleBindings, err := rbacClient.RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	objects = nil
+	for i := range roleBindings.Items {
+		roleBindings.Items[i].TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"}
+		objects = append(objects, &roleBindings.Items[i])
+	}
+	rendered = append(rendered, sortObjects(objects)...)
+
+	for _, obj := range rendered {
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+
+	return rendered, nil
+}
+
+func sortObjects(objects []client.Object) []client.Object {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].GetNamespace() != objects[j].GetNamespace() {
+			return objects[i].GetNamespace() < objects[j].GetNamespace()
+		}
+		return objects[i].GetName() < objects[j].GetName()
+	})
+
+	return objects
+}
+
+func isClusterNamespace(namespace *corev1.Namespace) bool {
+	_, hasOrganization := namespace.Labels[label.Organization]
+	_, hasCluster := namespace.Labels[label.Cluster]
+	return hasOrganization && hasCluster
+}
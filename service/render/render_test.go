@@ -0,0 +1,201 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+
+	"github.com/giantswarm/rbac-operator/pkg/protection"
+	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+)
+
+const testManifests = `
+apiVersion: security.giantswarm.io/v1alpha1
+kind: Organization
+metadata:
+  name: acme
+spec: {}
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: org-acme
+  labels:
+    giantswarm.io/organization: acme
+---
+apiVersion: auth.giantswarm.io/v1alpha1
+kind: RoleBindingTemplate
+metadata:
+  name: developers
+spec:
+  template:
+    metadata:
+      name: developers
+    roleRef:
+      apiGroup: rbac.authorization.k8s.io
+      kind: ClusterRole
+      name: view
+    subjects:
+    - kind: Group
+      name: developers
+  scopes:
+    organizationSelector: {}
+`
+
+func Test_Render(t *testing.T) {
+	testCases := []struct {
+		name              string
+		provider          string
+		manifests         string
+		protection        protection.Policy
+		expectedObjects   []string
+		unexpectedObjects []string
+		expectedErr       func(error) bool
+	}{
+		{
+			name:      "case 0: templates are rendered into organization namespaces",
+			provider:  "capz",
+			manifests: testManifests,
+			expectedObjects: []string{
+				"ClusterRole//read-all",
+				"ClusterRole//organization-acme-read",
+				"ClusterRoleBinding//write-all-customer-group",
+				"RoleBinding/org-acme/developers",
+				"RoleBinding/org-acme/write-all-customer-group",
+				"RoleBinding/org-acme/read-all-customer-group",
+				"RoleBinding/org-acme/cluster-ns-organization-acme-read",
+			},
+			unexpectedObjects: []string{
+				"ClusterRole//write-aws-cluster-role-identity",
+				"RoleBinding/org-acme/access-tier-platform-view",
+			},
+		},
+		{
+			name:      "case 1: provider specific resources are rendered",
+			provider:  "capa",
+			manifests: testManifests,
+			expectedObjects: []string{
+				"ClusterRole//write-aws-cluster-role-identity",
+				"RoleBinding/org-acme/developers",
+			},
+		},
+		{
+			name:      "case 2: the default namespace is added when missing",
+			provider:  "capa",
+			manifests: "",
+			expectedObjects: []string{
+				"ClusterRole//cluster-admin",
+				"ClusterRoleBinding//write-all-customer-group",
+			},
+			unexpectedObjects: []string{
+				"RoleBinding/org-acme/developers",
+			},
+		},
+		{
+			name:     "case 3: access tiers granted by AccessGroups are rendered",
+			provider: "capz",
+			manifests: testManifests + `---
+apiVersion: auth.giantswarm.io/v1alpha1
+kind: AccessGroup
+metadata:
+  name: acme-platform
+spec:
+  tier: platform
+  organizations:
+  - acme
+  subjects:
+  - name: acme:platform
+`,
+			expectedObjects: []string{
+				"RoleBinding/org-acme/access-tier-platform-view",
+			},
+		},
+		{
+			name:        "case 4: unknown kinds are rejected",
+			provider:    "capa",
+			manifests:   "apiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: x\n",
+			expectedErr: IsInvalidManifest,
+		},
+		{
+			name:      "case 5: subjects not allowed in protected namespaces are not bound",
+			provider:  "capz",
+			manifests: testManifests,
+			protection: protection.Policy{
+				Namespaces: []protection.Namespace{
+					{Name: "org-acme", AllowedSubjects: []protection.AllowedSubject{{Kind: "ServiceAccount", SameNamespace: true}}},
+				},
+			},
+			unexpectedObjects: []string{
+				"RoleBinding/org-acme/developers",
+				"RoleBinding/org-acme/write-all-customer-group",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer, err := New(Config{
+				Logger: microloggertest.New(),
+				AccessGroups: accessgroup.AccessGroups{
+					WriteAllCustomerGroups:   []accessgroup.AccessGroup{{Name: "customer:admins"}},
+					ReadAllCustomerGroups:    []accessgroup.AccessGroup{{Name: "customer:readers"}},
+					WriteAllGiantswarmGroups: []accessgroup.AccessGroup{{Name: "giantswarm:admins"}},
+					Tiers: []accessgroup.AccessTier{
+						{Name: "platform", OrganizationNamespaceClusterRoles: []string{"view"}},
+					},
+				},
+				Protection: tc.protection,
+				Provider:   tc.provider,
+			})
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+
+			objects, err := renderer.Decode(strings.NewReader(tc.manifests))
+			if tc.expectedErr != nil {
+				if err == nil || !tc.expectedErr(err) {
+					t.Fatalf("expected error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+
+			rendered, err := renderer.Render(context.Background(), objects)
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+
+			names := map[string]bool{}
+			for _, obj := range rendered {
+				kind := obj.GetObjectKind().GroupVersionKind().Kind
+				names[fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())] = true
+			}
+
+			for _, name := range tc.expectedObjects {
+				if !names[name] {
+					t.Fatalf("expected %s to be rendered", name)
+				}
+			}
+			for _, name := range tc.unexpectedObjects {
+				if names[name] {
+					t.Fatalf("expected %s not to be rendered", name)
+				}
+			}
+
+			var out bytes.Buffer
+			err = Write(&out, rendered)
+			if err != nil {
+				t.Fatalf("received unexpected error %s", err)
+			}
+			if strings.Count(out.String(), "\n---\n") != len(rendered)-1 {
+				t.Fatalf("expected %d documents in output", len(rendered))
+			}
+		})
+	}
+}
@@ -7,22 +7,33 @@ import (
 	"sync"
 
 	"github.com/giantswarm/rbac-operator/api/v1alpha1"
+	accessgroupcontroller "github.com/giantswarm/rbac-operator/service/controller/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/controller/clusterrolebindingtemplate"
 	"github.com/giantswarm/rbac-operator/service/controller/defaultnamespace"
 	"github.com/giantswarm/rbac-operator/service/controller/rolebindingtemplate"
+	"github.com/giantswarm/rbac-operator/service/controller/roletemplate"
 
 	"github.com/giantswarm/rbac-operator/service/internal/accessgroup"
+	"github.com/giantswarm/rbac-operator/service/internal/apiwatch"
 
 	"github.com/giantswarm/k8sclient/v8/pkg/k8sclient"
 	"github.com/giantswarm/k8sclient/v8/pkg/k8srestconfig"
+	"github.com/giantswarm/k8smetadata/pkg/label"
 	"github.com/giantswarm/microendpoint/service/version"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	security "github.com/giantswarm/organization-operator/api/v1alpha1"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/rbac-operator/flag"
+	pkglabel "github.com/giantswarm/rbac-operator/pkg/label"
 	"github.com/giantswarm/rbac-operator/pkg/project"
+	"github.com/giantswarm/rbac-operator/pkg/protection"
 	"github.com/giantswarm/rbac-operator/service/collector"
 	"github.com/giantswarm/rbac-operator/service/controller/clusternamespace"
 	"github.com/giantswarm/rbac-operator/service/controller/crossplane"
@@ -40,13 +51,25 @@ type Config struct {
 type Service struct {
 	Version *version.Service
 
-	bootOnce                      sync.Once
-	clusterController             *defaultnamespace.DefaultNamespace
-	rbacController                *rbac.RBAC
-	clusterNamespaceController    *clusternamespace.ClusterNamespace
-	crossplaneController          *crossplane.Crossplane
-	roleBindingTemplateController *rolebindingtemplate.RoleBindingTemplate
-	operatorCollector             *collector.Set
+	bootOnce                             sync.Once
+	accessGroupController                *accessgroupcontroller.AccessGroup
+	clusterController                    *defaultnamespace.DefaultNamespace
+	rbacController                       *rbac.RBAC
+	clusterNamespaceController           *clusternamespace.ClusterNamespace
+	crossplaneController                 *crossplane.Crossplane
+	roleBindingTemplateController        *rolebindingtemplate.RoleBindingTemplate
+	clusterRoleBindingTemplateController *clusterrolebindingtemplate.ClusterRoleBindingTemplate
+	roleTemplateController               *roletemplate.RoleTemplate
+	operatorCollector                    *collector.Set
+	apiWatch                             *apiwatch.Watch
+	informers                            ctrlcache.Cache
+
+	accessGroups *accessgroup.Store
+	flag         *flag.Flag
+	logger       micrologger.Logger
+	protection   protection.Policy
+	reloadMutex  sync.Mutex
+	viper        *viper.Viper
 }
 
 // New creates a new configured service object.
@@ -112,22 +135,60 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
-	var accessGroups accessgroup.AccessGroups
+	// informers are shared by everything triggered by changes of objects it
+	// does not reconcile itself, so that these objects are only read.
+	var informers ctrlcache.Cache
 	{
-		err = config.Viper.UnmarshalKey(config.Flag.Service.AccessGroups, &accessGroups)
+		// Only organization namespaces trigger anything
+		namespaceSelector, err := labels.Parse(label.Organization)
 		if err != nil {
-			// TODO: Log error
-			accessGroups = accessgroup.AccessGroups{}
+			return nil, microerror.Mask(err)
+		}
+		// Only config maps labelled as subjects sources trigger anything
+		configMapSelector, err := labels.Parse(pkglabel.SubjectsSource)
+		if err != nil {
+			return nil, microerror.Mask(err)
 		}
 
-		legacyCustomerAdminGroup := config.Viper.GetString(config.Flag.Service.WriteAllCustomerGroup)
-		accessGroups.AddLegacyCustomerAdminGroup(legacyCustomerAdminGroup)
+		c := ctrlcache.Options{
+			Scheme: k8sClient.Scheme(),
+			ByObject: map[client.Object]ctrlcache.ByObject{
+				&corev1.Namespace{}: {Label: namespaceSelector},
+				&corev1.ConfigMap{}: {Label: configMapSelector},
+			},
+		}
 
-		legacyGiantswarmAdminGroup := config.Viper.GetString(config.Flag.Service.WriteAllGiantswarmGroup)
-		accessGroups.AddLegacyGiantswarmAdminGroup(legacyGiantswarmAdminGroup)
+		informers, err = ctrlcache.New(k8sClient.RESTConfig(), c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
 
-		if !accessGroups.HasValidWriteAllGiantswarmAdminGroups() {
-			return nil, microerror.Maskf(invalidConfigError, "Giantswarm Write All Admin groups must not be empty")
+	var accessGroups *accessgroup.Store
+	{
+		groups, err := newAccessGroups(config.Viper, config.Flag)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		accessGroups = accessgroup.NewStore(groups)
+		logAccessGroupWarnings(context.Background(), config.Logger, accessGroups)
+	}
+
+	var protectionPolicy protection.Policy
+	{
+		var protectedNamespaces []protection.Namespace
+		err = config.Viper.UnmarshalKey(config.Flag.Service.ProtectedNamespaces, &protectedNamespaces)
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "protected namespaces could not be parsed: %v", err)
+		}
+
+		protectionPolicy = protection.DefaultPolicy()
+		if len(protectedNamespaces) > 0 {
+			protectionPolicy = protection.Policy{Namespaces: protectedNamespaces}
+			err = protectionPolicy.Validate()
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
 		}
 	}
 
@@ -137,12 +198,11 @@ func New(config Config) (*Service, error) {
 	var clusterController *defaultnamespace.DefaultNamespace
 	{
 		c := defaultnamespace.DefaultNamespaceConfig{
-			K8sClient:            k8sClient,
-			Logger:               config.Logger,
-			CustomerAdminGroups:  accessGroups.WriteAllCustomerGroups,
-			CustomerReaderGroups: accessGroups.ReadAllCustomerGroups,
-			GSAdminGroups:        accessGroups.WriteAllGiantswarmGroups,
-			Provider:             provider,
+			K8sClient:    k8sClient,
+			Logger:       config.Logger,
+			AccessGroups: accessGroups,
+			Protection:   protectionPolicy,
+			Provider:     provider,
 		}
 
 		clusterController, err = defaultnamespace.NewDefaultNamespace(c)
@@ -151,12 +211,29 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
+	var apiWatch *apiwatch.Watch
+	{
+		c := apiwatch.Config{
+			DynamicClient: k8sClient.DynClient(),
+			Logger:        config.Logger,
+
+			OnChange: clusterController.EnsureDiscoveryClusterRolesCreated,
+		}
+
+		apiWatch, err = apiwatch.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var clusterNamespaceController *clusternamespace.ClusterNamespace
 	{
 
 		c := clusternamespace.ClusterNamespaceConfig{
 			K8sClient: k8sClient,
 			Logger:    config.Logger,
+
+			Protection: protectionPolicy,
 		}
 
 		clusterNamespaceController, err = clusternamespace.NewClusterNamespace(c)
@@ -172,8 +249,9 @@ func New(config Config) (*Service, error) {
 			K8sClient: k8sClient,
 			Logger:    config.Logger,
 
-			WriteAllCustomerGroups: accessGroups.WriteAllCustomerGroups,
-			ReadAllCustomerGroups:  accessGroups.ReadAllCustomerGroups,
+			AccessGroups: accessGroups,
+			Informers:    informers,
+			Protection:   protectionPolicy,
 		}
 
 		rbacController, err = rbac.NewRBAC(c)
@@ -188,8 +266,7 @@ func New(config Config) (*Service, error) {
 			K8sClient: k8sClient,
 			Logger:    config.Logger,
 
-			CustomerAdminGroups:                 accessGroups.WriteAllCustomerGroups,
-			CustomerReaderGroups:                accessGroups.ReadAllCustomerGroups,
+			AccessGroups:                        accessGroups,
 			CrossplaneBindTriggeringClusterRole: config.Viper.GetString(config.Flag.Service.CrossplaneBindTriggeringClusterRoleName),
 		}
 
@@ -204,6 +281,10 @@ func New(config Config) (*Service, error) {
 		c := rolebindingtemplate.RoleBindingTemplateConfig{
 			K8sClient: k8sClient,
 			Logger:    config.Logger,
+
+			AccessGroups: accessGroups,
+			Informers:    informers,
+			Protection:   protectionPolicy,
 		}
 
 		roleBindingTemplateController, err = rolebindingtemplate.NewRoleBindingTemplate(c)
@@ -212,11 +293,41 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
+	var clusterRoleBindingTemplateController *clusterrolebindingtemplate.ClusterRoleBindingTemplate
+	{
+		c := clusterrolebindingtemplate.ClusterRoleBindingTemplateConfig{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+
+			Protection: protectionPolicy,
+		}
+
+		clusterRoleBindingTemplateController, err = clusterrolebindingtemplate.NewClusterRoleBindingTemplate(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var roleTemplateController *roletemplate.RoleTemplate
+	{
+		c := roletemplate.RoleTemplateConfig{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+		}
+
+		roleTemplateController, err = roletemplate.NewRoleTemplate(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var operatorCollector *collector.Set
 	{
 		c := collector.SetConfig{
 			K8sClient: k8sClient.K8sClient(),
 			Logger:    config.Logger,
+
+			AccessGroups: accessGroups,
 		}
 
 		operatorCollector, err = collector.NewSet(c)
@@ -244,21 +355,57 @@ func New(config Config) (*Service, error) {
 	s := &Service{
 		Version: versionService,
 
-		bootOnce:                      sync.Once{},
-		clusterController:             clusterController,
-		rbacController:                rbacController,
-		clusterNamespaceController:    clusterNamespaceController,
-		operatorCollector:             operatorCollector,
-		crossplaneController:          crossplaneController,
-		roleBindingTemplateController: roleBindingTemplateController,
+		bootOnce:                             sync.Once{},
+		clusterController:                    clusterController,
+		rbacController:                       rbacController,
+		clusterNamespaceController:           clusterNamespaceController,
+		operatorCollector:                    operatorCollector,
+		apiWatch:                             apiWatch,
+		informers:                            informers,
+		crossplaneController:                 crossplaneController,
+		roleBindingTemplateController:        roleBindingTemplateController,
+		clusterRoleBindingTemplateController: clusterRoleBindingTemplateController,
+		roleTemplateController:               roleTemplateController,
+
+		accessGroups: accessGroups,
+		flag:         config.Flag,
+		logger:       config.Logger,
+		protection:   protectionPolicy,
+		viper:        config.Viper,
+	}
+
+	{
+		c := accessgroupcontroller.AccessGroupConfig{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+
+			AccessGroups: accessGroups,
+			OnChange:     s.resyncAccessGroupConsumers,
+		}
+
+		s.accessGroupController, err = accessgroupcontroller.NewAccessGroup(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
 	}
 
 	return s, nil
 }
 
+// ProtectionPolicy returns the policy restricting the subjects bound in
+// protected namespaces, e.g. for the admission webhook.
+func (s *Service) ProtectionPolicy() protection.Policy {
+	return s.protection
+}
+
 func (s *Service) Boot(ctx context.Context) {
 	s.bootOnce.Do(func() {
-		err := s.clusterController.EnsureResourcesCreated(ctx)
+		err := s.accessGroupController.Load(ctx)
+		if err != nil {
+			s.logger.Errorf(ctx, err, "Could not load AccessGroups, starting with the configured access groups only")
+		}
+
+		err = s.clusterController.EnsureResourcesCreated(ctx)
 		if err != nil {
 			panic(microerror.JSON(microerror.Mask(err)))
 		}
@@ -279,5 +426,22 @@ func (s *Service) Boot(ctx context.Context) {
 		go s.crossplaneController.Boot(ctx)
 
 		go s.roleBindingTemplateController.Boot(ctx)
+
+		go s.clusterRoleBindingTemplateController.Boot(ctx)
+
+		go s.roleTemplateController.Boot(ctx)
+
+		go s.accessGroupController.Boot(ctx)
+
+		go s.apiWatch.Run(ctx)
+
+		go func() {
+			err := s.informers.Start(ctx)
+			if err != nil {
+				s.logger.Errorf(ctx, err, "Could not run the shared informers")
+			}
+		}()
+
+		s.watchAccessGroups(ctx)
 	})
 }
@@ -89,9 +89,22 @@ func Test_Service(t *testing.T) {
 }
 
 func mockKubernetesApiServer() *httptest.Server {
+	// The shared informers look up the API resources of the objects they watch.
+	discovery := map[string]string{
+		"/api":                                  `{"kind":"APIVersions","versions":["v1"]}`,
+		"/api/v1":                               `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"namespaces","singularName":"namespace","namespaced":false,"kind":"Namespace","verbs":["get","list","watch"]},{"name":"configmaps","singularName":"configmap","namespaced":true,"kind":"ConfigMap","verbs":["get","list","watch"]}]}`,
+		"/apis":                                 `{"kind":"APIGroupList","groups":[{"name":"security.giantswarm.io","versions":[{"groupVersion":"security.giantswarm.io/v1alpha1","version":"v1alpha1"}],"preferredVersion":{"groupVersion":"security.giantswarm.io/v1alpha1","version":"v1alpha1"}}]}`,
+		"/apis/security.giantswarm.io/v1alpha1": `{"kind":"APIResourceList","groupVersion":"security.giantswarm.io/v1alpha1","resources":[{"name":"organizations","singularName":"organization","namespaced":false,"kind":"Organization","verbs":["get","list","watch"]}]}`,
+	}
+
 	hf := func(w http.ResponseWriter, r *http.Request) {
+		body, ok := discovery[r.URL.Path]
+		if !ok {
+			body = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(200)
-		_, _ = w.Write([]byte("{}"))
+		_, _ = w.Write([]byte(body))
 	}
 	return httptest.NewServer(http.HandlerFunc(hf))
 }